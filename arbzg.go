@@ -0,0 +1,105 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Limits of the German Working Hours Act (Arbeitszeitgesetz).
+const (
+	ARBZG_MAX_DAILY = 10 * time.Hour   // §3
+	ARBZG_MIN_REST  = 11 * time.Hour   // §5
+	ARBZG_MIN_BREAK = 15 * time.Minute // §4, shorter breaks don't count
+)
+
+// Violation is a breach of the Working Hours Act on a day.
+type Violation struct {
+	Date    time.Time
+	Message string
+}
+
+// requiredBreak returns the break §4 ArbZG requires for the worked time.
+func requiredBreak(worked time.Duration) time.Duration {
+	switch {
+	case worked > 9*time.Hour:
+		return 45 * time.Minute
+	case worked > 6*time.Hour:
+		return 30 * time.Minute
+	}
+	return 0
+}
+
+func sortedByStart(entries []RowEntry) []RowEntry {
+	res := append([]RowEntry(nil), entries...)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Start.Before(res[j].Start) })
+	return res
+}
+
+// takenBreaks returns the breaks of the entries counting towards the required
+// break, i.e. their pauses and the gaps between them lasting at least
+// ARBZG_MIN_BREAK, and the recorded break intervals which are too short.
+func takenBreaks(entries []RowEntry) (time.Duration, []Break) {
+	var res time.Duration
+	var short []Break
+	entries = sortedByStart(entries)
+	for i, entry := range entries {
+		if len(entry.Breaks) == 0 && entry.Pause >= ARBZG_MIN_BREAK {
+			res += entry.Pause
+		}
+		for _, b := range entry.Breaks {
+			if b.Duration() >= ARBZG_MIN_BREAK {
+				res += b.Duration()
+			} else {
+				short = append(short, b)
+			}
+		}
+		if i > 0 && entries[i-1].HasEnd() && entry.Start.Sub(entries[i-1].End) >= ARBZG_MIN_BREAK {
+			res += entry.Start.Sub(entries[i-1].End)
+		}
+	}
+	return res, short
+}
+
+// CheckWorkingTimeAct checks the entries of date against the daily maximum,
+// the required breaks and the rest period after the entries of the day
+// before. Overnight entries count towards both days they span, absences
+// don't count at all.
+func CheckWorkingTimeAct(previous, day []RowEntry, date time.Time) []Violation {
+	var res []Violation
+	previous, day = workEntries(previous), workEntries(day)
+	if len(day) == 0 && len(previous) == 0 {
+		return nil
+	}
+
+	if worked := dayTotal(previous, day, date); worked > ARBZG_MAX_DAILY {
+		res = append(res, Violation{Date: date, Message: trf("Worked %s, more than the maximum of %s per day", formatDuration(worked), formatDuration(ARBZG_MAX_DAILY))})
+	}
+
+	if len(day) > 0 {
+		var worked time.Duration
+		for _, entry := range day {
+			worked += entry.Duration()
+		}
+		taken, short := takenBreaks(day)
+		if required := requiredBreak(worked); taken < required {
+			res = append(res, Violation{Date: date, Message: trf("Breaks of %s are shorter than the required %s", formatDuration(taken), formatDuration(required))})
+			for _, b := range short {
+				res = append(res, Violation{Date: date, Message: trf("Break %s is shorter than %s and does not count", b, formatDuration(ARBZG_MIN_BREAK))})
+			}
+		}
+	}
+
+	if len(previous) > 0 && len(day) > 0 {
+		var lastEnd time.Time
+		for _, entry := range previous {
+			if entry.End.After(lastEnd) {
+				lastEnd = entry.End
+			}
+		}
+		firstStart := sortedByStart(day)[0].Start
+		if rest := firstStart.Sub(lastEnd); rest < ARBZG_MIN_REST {
+			res = append(res, Violation{Date: date, Message: trf("Rest period of %s is shorter than the required %s", formatDuration(max(rest, 0)), formatDuration(ARBZG_MIN_REST))})
+		}
+	}
+	return res
+}
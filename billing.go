@@ -0,0 +1,272 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type BillingItem struct {
+	Date        time.Time
+	Description string
+	Duration    time.Duration // billable duration after rounding
+	DayOff      string        // "weekend" or "holiday" if worked on a day off
+}
+
+type BillingProject struct {
+	Project   Project
+	Rate      float64
+	Items     []BillingItem
+	Duration  time.Duration
+	DaysOff   time.Duration // thereof worked on weekends and holidays
+	Amount    float64       // including the surcharge
+	Surcharge float64
+}
+
+type BillingStatement struct {
+	Customer  string
+	From      time.Time
+	To        time.Time
+	Currency  string
+	Projects  []BillingProject
+	Duration  time.Duration
+	DaysOff   time.Duration
+	Surcharge float64
+	Total     float64
+}
+
+// roundBillable rounds d to the configured billing increment.
+func roundBillable(d time.Duration, config Configuration) time.Duration {
+	if config.BillingRoundingMinutes <= 0 {
+		return d
+	}
+	increment := time.Duration(config.BillingRoundingMinutes) * time.Minute
+	switch config.BillingRoundingMode {
+	case "down":
+		return d.Truncate(increment)
+	case "nearest":
+		return d.Round(increment)
+	default:
+		if rounded := d.Truncate(increment); rounded < d {
+			return rounded + increment
+		}
+		return d
+	}
+}
+
+// hourlyRate returns the rate of a project, preferring the configuration over
+// the project sheet.
+func hourlyRate(project Project, config Configuration) float64 {
+	if rate, ok := config.HourlyRates[project.ID]; ok {
+		return rate
+	}
+	if project.Rate > 0 {
+		return project.Rate
+	}
+	return config.DefaultHourlyRate
+}
+
+// CreateBillingStatement collects all entries of a customer between from and
+// to (both inclusive) and groups them by project.
+func CreateBillingStatement(entries [][][]RowEntry, projects map[string]Project, customer string, from, to time.Time, config Configuration) BillingStatement {
+	statement := BillingStatement{
+		Customer: customer,
+		From:     from,
+		To:       to,
+		Currency: config.Currency,
+	}
+	if statement.Currency == "" {
+		statement.Currency = "EUR"
+	}
+
+	byProject := make(map[string]*BillingProject)
+	for _, month := range entries {
+		for _, day := range month {
+			for _, entry := range day {
+				if entry.Date.Before(from) || entry.Date.After(to) {
+					continue
+				}
+				project, ok := projects[entry.ProjectNr]
+				if !ok {
+					project = Project{ID: entry.ProjectNr, Name: entry.Project, Customer: entry.Customer}
+				}
+				if project.Customer != customer && entry.Customer != customer {
+					continue
+				}
+
+				billingProject, ok := byProject[project.ID]
+				if !ok {
+					billingProject = &BillingProject{Project: project, Rate: hourlyRate(project, config)}
+					byProject[project.ID] = billingProject
+				}
+				duration := billableDuration(entry, config)
+				item := BillingItem{
+					Date:        entry.Date,
+					Description: entry.Description,
+					Duration:    duration,
+				}
+				// overnight entries may be partly on a day off
+				for _, part := range entry.Parts() {
+					if label := dayOffLabel(part.Date, config); label != "" {
+						item.DayOff = label
+						billingProject.DaysOff += min(billableDuration(part, config), duration)
+					}
+				}
+				billingProject.Items = append(billingProject.Items, item)
+				billingProject.Duration += duration
+			}
+		}
+	}
+
+	for _, billingProject := range byProject {
+		billingProject.Surcharge = billingProject.DaysOff.Hours() * billingProject.Rate * config.WeekendSurcharge / 100
+		billingProject.Amount = billingProject.Duration.Hours()*billingProject.Rate + billingProject.Surcharge
+		statement.Projects = append(statement.Projects, *billingProject)
+		statement.Duration += billingProject.Duration
+		statement.DaysOff += billingProject.DaysOff
+		statement.Surcharge += billingProject.Surcharge
+		statement.Total += billingProject.Amount
+	}
+	sort.Slice(statement.Projects, func(i, j int) bool {
+		return statement.Projects[i].Project.ID < statement.Projects[j].Project.ID
+	})
+
+	return statement
+}
+
+var billingTemplate = template.Must(template.New("billing").Funcs(template.FuncMap{
+	"date":  func(t time.Time) string { return t.Format("02.01.2006") },
+	"hours": formatHHMM,
+	"money": func(f float64) string { return fmt.Sprintf("%.2f", f) },
+	"tr":    tr,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{tr "Statement"}} {{.Customer}}</title>
+<style>
+  body { font-family: sans-serif; font-size: 11pt; margin: 2cm; }
+  table { width: 100%; border-collapse: collapse; margin-bottom: 1em; }
+  th, td { border-bottom: 1px solid #ccc; padding: 4px; text-align: left; }
+  td.num, th.num { text-align: right; }
+  tr.subtotal td { font-weight: bold; border-bottom: 2px solid #000; }
+  @media print { body { margin: 0; } h2 { page-break-after: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{tr "Statement for"}} {{.Customer}}</h1>
+<p>{{tr "Period"}}: {{date .From}} – {{date .To}}</p>
+{{range .Projects}}
+<h2>{{.Project.ID}} {{.Project.Name}}</h2>
+<table>
+  <tr><th>{{tr "Date"}}</th><th>{{tr "Description"}}</th><th class="num">{{tr "Hours"}}</th></tr>
+  {{range .Items}}<tr><td>{{date .Date}}{{if .DayOff}} ({{tr .DayOff}}){{end}}</td><td>{{.Description}}</td><td class="num">{{hours .Duration}}</td></tr>
+  {{end}}{{if .Surcharge}}<tr><td colspan="2">{{tr "Surcharge for weekends and holidays"}} ({{hours .DaysOff}})</td><td class="num">{{money .Surcharge}} {{$.Currency}}</td></tr>
+  {{end}}<tr class="subtotal"><td colspan="2">{{tr "Subtotal"}} ({{money .Rate}} {{$.Currency}}/h)</td><td class="num">{{hours .Duration}} = {{money .Amount}} {{$.Currency}}</td></tr>
+</table>
+{{end}}
+<h2>{{tr "Total"}}: {{hours .Duration}} = {{money .Total}} {{.Currency}}</h2>
+</body>
+</html>
+`))
+
+// WriteBillingHTML renders the statement as a standalone, printable HTML file.
+func WriteBillingHTML(statement BillingStatement, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return billingTemplate.Execute(file, statement)
+}
+
+// WriteBillingSheet adds the statement as a new sheet to f. An existing sheet
+// with the same name is replaced.
+func WriteBillingSheet(f *excelize.File, statement BillingStatement, sheetName string) error {
+	if index, _ := f.GetSheetIndex(sheetName); index >= 0 {
+		if err := f.DeleteSheet(sheetName); err != nil {
+			return err
+		}
+	}
+	if _, err := f.NewSheet(sheetName); err != nil {
+		return err
+	}
+
+	row := 1
+	setRow := func(values ...interface{}) {
+		f.SetSheetRow(sheetName, fmt.Sprintf("A%d", row), &values)
+		row += 1
+	}
+
+	setRow(tr("Statement"), statement.Customer)
+	setRow(tr("Period"), statement.From.Format("02.01.2006"), statement.To.Format("02.01.2006"))
+	row += 1
+	for _, project := range statement.Projects {
+		setRow(project.Project.ID, project.Project.Name)
+		setRow(tr("Date"), tr("Description"), tr("Hours"))
+		for _, item := range project.Items {
+			setRow(item.Date.Format("02.01.2006"), item.Description, item.Duration.Hours(), tr(item.DayOff))
+		}
+		if project.Surcharge > 0 {
+			setRow(tr("Surcharge for weekends and holidays"), "", project.DaysOff.Hours(), project.Surcharge)
+		}
+		setRow(tr("Subtotal"), fmt.Sprintf("%.2f %s/h", project.Rate, statement.Currency), project.Duration.Hours(), project.Amount)
+		row += 1
+	}
+	setRow(tr("Total"), statement.Currency, statement.Duration.Hours(), statement.Total)
+
+	f.SetColWidth(sheetName, "B", "B", 50)
+	return nil
+}
+
+// WriteBillingWorkbook writes the statement to a new workbook at path, which
+// contains nothing but the statement.
+func WriteBillingWorkbook(statement BillingStatement, path string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+	blank := f.GetSheetName(0)
+	if err := WriteBillingSheet(f, statement, "Abrechnung"); err != nil {
+		return err
+	}
+	if err := f.DeleteSheet(blank); err != nil {
+		return err
+	}
+	f.SetActiveSheet(0)
+	return f.SaveAs(path)
+}
+
+// RunBillingExport creates the statement for customer and writes it to the
+// given HTML and/or workbook file.
+func RunBillingExport(config Configuration, customer, from, to, htmlFile, xlsxFile string) error {
+	fromDate, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return fmt.Errorf("invalid start date %q: %w", from, err)
+	}
+	toDate, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return fmt.Errorf("invalid end date %q: %w", to, err)
+	}
+	if htmlFile == "" && xlsxFile == "" {
+		return errors.New("neither an HTML nor a workbook output file was given")
+	}
+
+	projects, _, _ := GetProjectNumbers(config)
+	statement := CreateBillingStatement(ReturnAll(config), projects, customer, fromDate, toDate, config)
+	slog.Info("Created billing statement", "customer", customer, "projects", len(statement.Projects), "total", statement.Total)
+
+	if htmlFile != "" {
+		if err := WriteBillingHTML(statement, htmlFile); err != nil {
+			return err
+		}
+	}
+	if xlsxFile != "" {
+		return WriteBillingWorkbook(statement, xlsxFile)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCreateBillingStatement(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.January, d, 0, 0, 0, 0, time.UTC) }
+	entry := func(d int, start, end time.Duration, projectNr string) RowEntry {
+		return RowEntry{Date: day(d), Start: day(d).Add(start), End: day(d).Add(end), ProjectNr: projectNr, Description: "Work"}
+	}
+
+	entries := make([][][]RowEntry, 12)
+	entries[0] = make([][]RowEntry, 31)
+	entries[0][1] = []RowEntry{
+		entry(2, 8*time.Hour, 9*time.Hour+10*time.Minute, "2024-1310"),
+		entry(2, 10*time.Hour, 11*time.Hour, "2024-0001"),
+	}
+	entries[0][2] = []RowEntry{entry(3, 8*time.Hour, 8*time.Hour+20*time.Minute, "2024-1310")}
+	entries[0][20] = []RowEntry{entry(21, 8*time.Hour, 9*time.Hour, "2024-1310")}
+
+	projects := map[string]Project{
+		"2024-1310": {ID: "2024-1310", Name: "Portal", Customer: "ACME", Rate: 100},
+		"2024-0001": {ID: "2024-0001", Name: "Internal", Customer: "Us"},
+	}
+	config := Configuration{BillingRoundingMinutes: 15, BillingRoundingMode: "up"}
+
+	statement := CreateBillingStatement(entries, projects, "ACME", day(1), day(10), config)
+
+	if len(statement.Projects) != 1 {
+		t.Fatalf("expected one project, got %d", len(statement.Projects))
+	}
+	project := statement.Projects[0]
+	if len(project.Items) != 2 {
+		t.Fatalf("expected two items, got %d", len(project.Items))
+	}
+	// 1:10 -> 1:15 and 0:20 -> 0:30
+	if project.Duration != 105*time.Minute {
+		t.Errorf("expected 1:45 billable, got %s", project.Duration)
+	}
+	if statement.Total != 175 {
+		t.Errorf("expected total of 175, got %.2f", statement.Total)
+	}
+}
+
+func TestWriteBillingWorkbook(t *testing.T) {
+	statement := BillingStatement{Customer: "ACME", Currency: "EUR", Total: 175}
+	path := filepath.Join(t.TempDir(), "statement.xlsx")
+	if err := WriteBillingWorkbook(statement, path); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if sheets := f.GetSheetList(); !slices.Equal(sheets, []string{"Abrechnung"}) {
+		t.Errorf("expected only the statement in the workbook, got %v", sheets)
+	}
+	if customer, _ := f.GetCellValue("Abrechnung", "B1"); customer != "ACME" {
+		t.Errorf("expected the customer in B1, got %q", customer)
+	}
+}
+
+func TestRoundBillable(t *testing.T) {
+	d := 67 * time.Minute
+	for mode, expected := range map[string]time.Duration{
+		"up":      75 * time.Minute,
+		"down":    60 * time.Minute,
+		"nearest": 60 * time.Minute,
+	} {
+		config := Configuration{BillingRoundingMinutes: 15, BillingRoundingMode: mode}
+		if res := roundBillable(d, config); res != expected {
+			t.Errorf("mode %s: expected %s, got %s", mode, expected, res)
+		}
+	}
+}
+
+func TestBillingWeekendSurcharge(t *testing.T) {
+	saturday := time.Date(2025, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := saturday.AddDate(0, 0, 2)
+
+	entries := make([][][]RowEntry, 12)
+	entries[0] = make([][]RowEntry, 31)
+	entries[0][3] = []RowEntry{{Date: saturday, Start: saturday.Add(8 * time.Hour), End: saturday.Add(10 * time.Hour), ProjectNr: "2024-1310"}}
+	entries[0][5] = []RowEntry{{Date: monday, Start: monday.Add(8 * time.Hour), End: monday.Add(10 * time.Hour), ProjectNr: "2024-1310"}}
+
+	projects := map[string]Project{"2024-1310": {ID: "2024-1310", Customer: "ACME", Rate: 100}}
+	config := Configuration{WeekendSurcharge: 50}
+
+	statement := CreateBillingStatement(entries, projects, "ACME", saturday, monday, config)
+	if statement.DaysOff != 2*time.Hour {
+		t.Errorf("expected 2h on weekends, got %s", statement.DaysOff)
+	}
+	if statement.Projects[0].Items[0].DayOff != "weekend" {
+		t.Errorf("expected saturday to be flagged as weekend, got %q", statement.Projects[0].Items[0].DayOff)
+	}
+	// 4h * 100 + 2h * 50
+	if statement.Total != 500 {
+		t.Errorf("expected total of 500, got %.2f", statement.Total)
+	}
+
+	// a six day week only has sunday off
+	config.WeekendDays = []string{"So"}
+	if workTime := SumWorkTime(entries[0], config); workTime.DaysOff != 0 || workTime.Workdays != 4*time.Hour {
+		t.Errorf("expected saturday to be a workday, got %+v", workTime)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BREAKS_PREFIX marks the line of the note of an entry listing its break
+// intervals, e.g. "Pausen: 12:00-12:30, 15:10-15:20". The Pause column holds
+// their total.
+const BREAKS_PREFIX = "Pausen:"
+
+// Break is a break interval within an entry.
+type Break struct {
+	Start Clock
+	End   Clock
+}
+
+func (b Break) Duration() time.Duration {
+	return time.Duration(b.End-b.Start) * time.Minute
+}
+
+func (b Break) String() string {
+	return fmt.Sprintf("%s-%s", b.Start, b.End%MINUTES_PER_DAY)
+}
+
+// ParseBreaks parses comma separated break intervals like
+// "12:00-12:30, 15:10-15:20". Breaks ending before they start end on the
+// following day.
+func ParseBreaks(s string) ([]Break, error) {
+	var res []Break
+	for _, interval := range strings.Split(s, ",") {
+		if strings.TrimSpace(interval) == "" {
+			continue
+		}
+		start, end, found := strings.Cut(interval, "-")
+		if !found {
+			return nil, fmt.Errorf("invalid break %q, expected hh:mm-hh:mm", strings.TrimSpace(interval))
+		}
+		var b Break
+		var err error
+		if b.Start, err = ParseClock(start); err != nil {
+			return nil, err
+		}
+		if b.End, err = ParseClock(end); err != nil {
+			return nil, err
+		}
+		if b.End < b.Start {
+			b.End += MINUTES_PER_DAY
+		}
+		res = append(res, b)
+	}
+	return res, nil
+}
+
+func validateBreaks(s string) error {
+	_, err := ParseBreaks(s)
+	return err
+}
+
+// formatBreaks formats breaks like ParseBreaks expects them.
+func formatBreaks(breaks []Break) string {
+	intervals := make([]string, len(breaks))
+	for i, b := range breaks {
+		intervals[i] = b.String()
+	}
+	return strings.Join(intervals, ", ")
+}
+
+// breaksFromNote returns the break intervals listed in a note.
+func breaksFromNote(note string) []Break {
+	for _, line := range strings.Split(note, "\n") {
+		if intervals, found := strings.CutPrefix(strings.TrimSpace(line), BREAKS_PREFIX); found {
+			breaks, err := ParseBreaks(intervals)
+			if err != nil {
+				return nil
+			}
+			return breaks
+		}
+	}
+	return nil
+}
+
+// noteWithBreaks returns note with its line of break intervals replaced by
+// breaks, keeping everything else written into the note.
+func noteWithBreaks(note string, breaks []Break) string {
+	var lines []string
+	for _, line := range strings.Split(note, "\n") {
+		if line != "" && !strings.HasPrefix(strings.TrimSpace(line), BREAKS_PREFIX) {
+			lines = append(lines, line)
+		}
+	}
+	if len(breaks) > 0 {
+		lines = append(lines, BREAKS_PREFIX+" "+formatBreaks(breaks))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetBreaks records the break intervals of the entry in its note and sets
+// the pause to their total.
+func (r *RowEntry) SetBreaks(breaks []Break) {
+	r.Breaks = breaks
+	r.Note = noteWithBreaks(r.Note, breaks)
+	if len(breaks) == 0 {
+		return
+	}
+	r.Pause = 0
+	for _, b := range breaks {
+		r.Pause += b.Duration()
+	}
+}
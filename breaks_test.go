@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBreaks(t *testing.T) {
+	breaks, err := ParseBreaks("12:00-12:30, 15:10-15:20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breaks) != 2 || breaks[0].Duration() != 30*time.Minute || breaks[1].Duration() != 10*time.Minute {
+		t.Errorf("unexpected breaks %v", breaks)
+	}
+	if s := formatBreaks(breaks); s != "12:00-12:30, 15:10-15:20" {
+		t.Errorf("unexpected formatted breaks %q", s)
+	}
+	for _, s := range []string{"12:00", "12:00-", "12-13"} {
+		if _, err := ParseBreaks(s); err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+}
+
+func TestBreaksInNote(t *testing.T) {
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	entry := RowEntry{Date: date, Start: Clock(8 * 60).On(date), End: Clock(17 * 60).On(date), Note: "Kundentermin"}
+	breaks, _ := ParseBreaks("12:00-12:30, 15:10-15:20")
+	entry.SetBreaks(breaks)
+	if entry.Pause != 40*time.Minute {
+		t.Errorf("expected a pause of 40m, got %s", entry.Pause)
+	}
+	if entry.Note != "Kundentermin\nPausen: 12:00-12:30, 15:10-15:20" {
+		t.Errorf("unexpected note %q", entry.Note)
+	}
+
+	row := []string{"45720", "Di", "0.333333333333333", "0.708333333333333", "2.7777777777777776E-2", "2024-1310", "Portal", "ACME", "Workshop", "8.33", "", "", entry.Note}
+	read, err := ReadEntryFromRow(row, "03", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Breaks) != 2 || read.Breaks[1].Start != 15*60+10 {
+		t.Errorf("expected the breaks to be read from the note, got %v", read.Breaks)
+	}
+
+	entry.SetBreaks(nil)
+	if entry.Note != "Kundentermin" || entry.Pause != 40*time.Minute {
+		t.Errorf("expected removing the breaks to keep the note and pause, got %q and %s", entry.Note, entry.Pause)
+	}
+}
+
+func TestShortBreaksDontCount(t *testing.T) {
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	entry := RowEntry{Date: date, Start: Clock(8 * 60).On(date), End: Clock(15 * 60).On(date)}
+	breaks, _ := ParseBreaks("11:00-11:10, 12:00-12:20")
+	entry.SetBreaks(breaks)
+
+	// 6:30 worked, but only the break of 20 minutes counts
+	violations := CheckWorkingTimeAct(nil, []RowEntry{entry}, date)
+	if len(violations) != 2 {
+		t.Fatalf("expected the required break and the short break to be reported, got %v", violations)
+	}
+	if violations[1].Message != "Break 11:00-11:10 is shorter than 0:15 and does not count" {
+		t.Errorf("unexpected message %q", violations[1].Message)
+	}
+
+	breaks, _ = ParseBreaks("11:00-11:15, 12:00-12:15")
+	entry.SetBreaks(breaks)
+	if violations := CheckWorkingTimeAct(nil, []RowEntry{entry}, date); len(violations) != 0 {
+		t.Errorf("expected two breaks of 15 minutes to suffice, got %v", violations)
+	}
+}
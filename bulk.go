@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// entryPosition identifies an entry by its day and index within that day.
+type entryPosition struct {
+	Date time.Time
+	Row  int
+}
+
+func (p entryPosition) before(o entryPosition) bool {
+	if !p.Date.Equal(o.Date) {
+		return p.Date.Before(o.Date)
+	}
+	return p.Row < o.Row
+}
+
+// Selection spans all entries between an anchor and a cursor position, which
+// may lie on different days.
+type Selection struct {
+	Anchor entryPosition
+	Cursor entryPosition
+}
+
+func NewSelection(date time.Time, row int) Selection {
+	pos := entryPosition{Date: toSheetDate(date), Row: row}
+	return Selection{Anchor: pos, Cursor: pos}
+}
+
+func (s Selection) bounds() (entryPosition, entryPosition) {
+	if s.Cursor.before(s.Anchor) {
+		return s.Cursor, s.Anchor
+	}
+	return s.Anchor, s.Cursor
+}
+
+// Contains reports whether the entry at row of date is selected.
+func (s Selection) Contains(date time.Time, row int) bool {
+	pos := entryPosition{Date: toSheetDate(date), Row: row}
+	from, to := s.bounds()
+	return !pos.before(from) && !to.before(pos)
+}
+
+// selectedRows calls f with every day touched by the selection and the
+// indices of its selected entries.
+func (e *EntryList) selectedRows(s Selection, f func(day *[]RowEntry, rows []int)) {
+	from, to := s.bounds()
+	for date := from.Date; !date.After(to.Date); date = date.AddDate(0, 0, 1) {
+		day := e.dayEntries(date)
+		if day == nil || len(*day) == 0 {
+			continue
+		}
+		first, last := 0, len(*day)-1
+		if date.Equal(from.Date) {
+			first = from.Row
+		}
+		if date.Equal(to.Date) && to.Row < last {
+			last = to.Row
+		}
+		var rows []int
+		for row := first; row <= last; row++ {
+			rows = append(rows, row)
+		}
+		if len(rows) > 0 {
+			f(day, rows)
+		}
+	}
+}
+
+// SelectedEntries returns pointers to all selected entries except absences.
+func (e *EntryList) SelectedEntries(s Selection) []*RowEntry {
+	var res []*RowEntry
+	e.selectedRows(s, func(day *[]RowEntry, rows []int) {
+		for _, row := range rows {
+			if (*day)[row].IsAbsence() {
+				continue
+			}
+			res = append(res, &(*day)[row])
+		}
+	})
+	return res
+}
+
+// DeleteSelection removes all selected entries and returns their number.
+func (e *EntryList) DeleteSelection(s Selection) int {
+	deleted := 0
+	e.selectedRows(s, func(day *[]RowEntry, rows []int) {
+		*day = append((*day)[:rows[0]], (*day)[rows[len(rows)-1]+1:]...)
+		deleted += len(rows)
+	})
+	return deleted
+}
+
+// ApplyBulkCommand applies a command to all selected entries. Supported
+// commands are
+//
+//	project <nr>        change the project number
+//	replace <old>/<new> replace text in descriptions
+//	shift <duration>    shift start and end, e.g. "shift -0:15" or "shift -15m"
+//	pause <duration>    set the pause
+//	delete              delete the entries
+//
+// It returns the number of affected entries.
+func (e *EntryList) ApplyBulkCommand(s Selection, command string, projects map[string]Project) (int, error) {
+	name, arg, _ := strings.Cut(strings.TrimSpace(command), " ")
+	arg = strings.TrimSpace(arg)
+
+	if name == "delete" {
+		return e.DeleteSelection(s), nil
+	}
+
+	entries := e.SelectedEntries(s)
+	switch name {
+	case "project":
+		if arg == "" {
+			return 0, errors.New("missing project number")
+		}
+		if _, ok := projects[arg]; !ok && len(projects) > 0 {
+			return 0, fmt.Errorf("unknown project number %q", arg)
+		}
+		for _, entry := range entries {
+			entry.ProjectNr = arg
+			entry.Project = projects[arg].Name
+			entry.Customer = projects[arg].Customer
+		}
+	case "replace":
+		old, replacement, found := strings.Cut(arg, "/")
+		if !found || old == "" {
+			return 0, errors.New("expected replace <old>/<new>")
+		}
+		for _, entry := range entries {
+			entry.Description = strings.ReplaceAll(entry.Description, old, replacement)
+		}
+	case "shift":
+		offset, err := parseSignedDuration(arg)
+		if err != nil {
+			return 0, fmt.Errorf("invalid offset: %w", err)
+		}
+		for _, entry := range entries {
+			entry.Start = entry.Start.Add(offset)
+			entry.End = entry.End.Add(offset)
+		}
+	case "pause":
+		pause, err := parseDuration(arg)
+		if err != nil {
+			return 0, fmt.Errorf("invalid pause: %w", err)
+		}
+		for _, entry := range entries {
+			entry.Pause = pause
+		}
+	default:
+		return 0, fmt.Errorf("unknown command %q", name)
+	}
+	return len(entries), nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func newBulkTestEntries() EntryList {
+	entries := make([][][]RowEntry, 12)
+	entries[0] = make([][]RowEntry, 31)
+	for d := 6; d <= 8; d++ {
+		date := time.Date(2025, time.January, d, 0, 0, 0, 0, time.UTC)
+		for h := 8; h < 11; h++ {
+			entries[0][d-1] = append(entries[0][d-1], RowEntry{
+				Date:        date,
+				Start:       date.Add(time.Duration(h) * time.Hour),
+				End:         date.Add(time.Duration(h+1) * time.Hour),
+				ProjectNr:   "2024-0001",
+				Description: "Old project",
+			})
+		}
+	}
+	return EntryList{Entries: entries}
+}
+
+func TestSelectionAcrossDays(t *testing.T) {
+	entryList := newBulkTestEntries()
+	selection := NewSelection(time.Date(2025, time.January, 8, 0, 0, 0, 0, time.UTC), 0)
+	selection.Cursor = entryPosition{Date: time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC), Row: 2}
+
+	// last entry of the 6th, all of the 7th and first of the 8th
+	if selected := entryList.SelectedEntries(selection); len(selected) != 5 {
+		t.Fatalf("expected 5 selected entries, got %d", len(selected))
+	}
+
+	projects := map[string]Project{"2025-0002": {ID: "2025-0002", Name: "New", Customer: "ACME"}}
+	if _, err := entryList.ApplyBulkCommand(selection, "project 2025-0002", projects); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := entryList.ApplyBulkCommand(selection, "replace Old/New", projects); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := entryList.ApplyBulkCommand(selection, "shift -15m", projects); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := entryList.ApplyBulkCommand(selection, "unknown", projects); err == nil {
+		t.Error("expected unknown command to fail")
+	}
+
+	changed := entryList.Entries[0][6][1]
+	if changed.ProjectNr != "2025-0002" || changed.Customer != "ACME" || changed.Description != "New project" {
+		t.Errorf("entry was not changed: %+v", changed)
+	}
+	if changed.Start.Hour() != 8 || changed.Start.Minute() != 45 {
+		t.Errorf("expected start to be shifted to 08:45, got %s", changed.Start.Format("15:04"))
+	}
+	if untouched := entryList.Entries[0][5][1]; untouched.ProjectNr != "2024-0001" {
+		t.Errorf("entry outside of the selection was changed: %+v", untouched)
+	}
+
+	if deleted := entryList.DeleteSelection(selection); deleted != 5 {
+		t.Errorf("expected 5 deleted entries, got %d", deleted)
+	}
+	if len(entryList.Entries[0][5]) != 2 || len(entryList.Entries[0][6]) != 0 || len(entryList.Entries[0][7]) != 2 {
+		t.Errorf("unexpected entries after deletion: %d %d %d", len(entryList.Entries[0][5]), len(entryList.Entries[0][6]), len(entryList.Entries[0][7]))
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dates and times of entries follow a civil date/wall clock model: the date
+// of an entry is its sheet date at midnight UTC (see toSheetDate) and start
+// and end are that date plus the wall clock time as minutes. This keeps them
+// independent of the local time zone and DST, which only matter when
+// computing how long someone actually worked (see RowEntry.Duration).
+
+// workLocation is the time zone the wall clock times of the workbook are in.
+var workLocation = time.Local
+
+// Clock is a wall clock time in minutes since midnight. Times after midnight
+// of the following day, e.g. the end of a night shift, are 24:00 and later.
+type Clock int
+
+const MINUTES_PER_DAY = 24 * 60
+
+// ParseClock parses a wall clock time like "7:30", "15:04" or "24:00".
+func ParseClock(s string) (Clock, error) {
+	hours, minutes, found := strings.Cut(strings.TrimSpace(s), ":")
+	if !found || len(minutes) != 2 {
+		return 0, fmt.Errorf("invalid time %q, expected hh:mm", s)
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 || (h == 24 && m > 0) {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return Clock(h*60 + m), nil
+}
+
+// clockFromFraction converts an Excel time, a fraction of a day, to the
+// nearest minute.
+func clockFromFraction(f float64) Clock {
+	return Clock(math.Round(f * MINUTES_PER_DAY))
+}
+
+// clockSince returns the wall clock time of t relative to the midnight
+// starting date.
+func clockSince(date, t time.Time) Clock {
+	return Clock(t.Sub(toSheetDate(date)) / time.Minute)
+}
+
+func (c Clock) Fraction() float64 {
+	return float64(c) / MINUTES_PER_DAY
+}
+
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+// On returns the time of the wall clock on date.
+func (c Clock) On(date time.Time) time.Time {
+	return toSheetDate(date).Add(time.Duration(c) * time.Minute)
+}
+
+// inWorkLocation interprets the wall clock of t in workLocation.
+func inWorkLocation(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, workLocation)
+}
+
+// StartClock and EndClock return the wall clock times of the entry relative
+// to the midnight starting its date.
+func (r RowEntry) StartClock() Clock {
+	return clockSince(r.Date, r.Start)
+}
+
+func (r RowEntry) EndClock() Clock {
+	return clockSince(r.Date, r.End)
+}
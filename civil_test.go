@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseClock(t *testing.T) {
+	for s, expected := range map[string]Clock{"7:30": 450, "15:04": 904, "00:00": 0, "24:00": 1440} {
+		if c, err := ParseClock(s); err != nil || c != expected {
+			t.Errorf("parsing %q: expected %d, got %d (%v)", s, expected, c, err)
+		}
+	}
+	for _, s := range []string{"", "7", "7:3", "25:00", "24:30", "12:60", "ab:cd"} {
+		if _, err := ParseClock(s); err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+	if s := Clock(1440).String(); s != "24:00" {
+		t.Errorf("unexpected clock %q", s)
+	}
+}
+
+func TestReadTimesAsWallClock(t *testing.T) {
+	date := excelDateToDate("45658.75")
+	if !date.Equal(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %s", date)
+	}
+	if s := dateToExcelDate(date); s != "45658" {
+		t.Errorf("unexpected serial date %s", s)
+	}
+
+	// Excel stores 08:00 and 30 minutes with rounding errors
+	start := calcTimeFromFloat(date, "0.33333333333333298")
+	if clockSince(date, start) != 8*60 {
+		t.Errorf("unexpected start %s", start)
+	}
+	if pause := calcDurationFromFloat("2.0833333333333332E-2"); pause != 30*time.Minute {
+		t.Errorf("unexpected pause %s", pause)
+	}
+	// times written as text by older versions
+	if end := calcTimeFromFloat(date, "16:30"); clockSince(date, end) != 16*60+30 {
+		t.Errorf("unexpected end %s", end)
+	}
+}
+
+func TestDurationAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(loc *time.Location) { workLocation = loc }(workLocation)
+	workLocation = berlin
+
+	entry := func(date time.Time, start, end Clock) RowEntry {
+		return RowEntry{Date: date, Start: start.On(date), End: end.On(date)}
+	}
+	// clocks skip 02:00 - 03:00
+	springForward := time.Date(2025, time.March, 30, 0, 0, 0, 0, time.UTC)
+	if d := entry(springForward, 60, 4*60).Duration(); d != 2*time.Hour {
+		t.Errorf("expected 2h on the day DST starts, got %s", d)
+	}
+	// clocks repeat 02:00 - 03:00
+	fallBack := time.Date(2025, time.October, 26, 0, 0, 0, 0, time.UTC)
+	if d := entry(fallBack, 60, 4*60).Duration(); d != 4*time.Hour {
+		t.Errorf("expected 4h on the day DST ends, got %s", d)
+	}
+	if d := entry(fallBack, 22*60, 24*60).Duration(); d != 2*time.Hour {
+		t.Errorf("expected 2h until midnight, got %s", d)
+	}
+
+	// moving entries keeps their wall clock times
+	moved := retargetEntries([]RowEntry{entry(springForward, 8*60, 24*60)}, fallBack, "10")[0]
+	if moved.StartClock() != 8*60 || moved.EndClock() != 24*60 {
+		t.Errorf("unexpected times after moving: %s - %s", moved.StartClock(), moved.EndClock())
+	}
+}
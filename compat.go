@@ -0,0 +1,179 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	COMPAT_EXCEL       = "excel"
+	COMPAT_LIBREOFFICE = "libreoffice"
+)
+
+var (
+	// sheet references as written by LibreOffice in its own format
+	odfSheetReference = regexp.MustCompile(`\$?'([^']+)'\.`)
+	calcPrTag         = regexp.MustCompile(`<calcPr(\s[^>]*?)?(/?)>`)
+	fullCalcOnLoad    = regexp.MustCompile(`\sfullCalcOnLoad="[^"]*"`)
+	formulaElement    = regexp.MustCompile(`<f(\s[^>]*)?>([^<]*)</f>`)
+)
+
+// needsQuoting reports whether a sheet name has to be quoted in formulas,
+// which is the case for names starting with a digit like "01".
+func needsQuoting(sheet string) bool {
+	if sheet == "" {
+		return false
+	}
+	if sheet[0] >= '0' && sheet[0] <= '9' {
+		return true
+	}
+	for _, c := range sheet {
+		if !(c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSheetReferences rewrites all sheet references of formula to the
+// quoted form 'sheet'! understood by both Excel and LibreOffice.
+func normalizeSheetReferences(formula string, sheets []string) string {
+	formula = odfSheetReference.ReplaceAllString(formula, "'$1'!")
+	for _, sheet := range sheets {
+		if !needsQuoting(sheet) {
+			continue
+		}
+		unquoted := regexp.MustCompile(`(^|[^'\w.])` + regexp.QuoteMeta(sheet) + `!`)
+		formula = unquoted.ReplaceAllString(formula, "${1}'"+strings.ReplaceAll(sheet, "'", "''")+"'!")
+	}
+	return formula
+}
+
+// normalizeFormulas rewrites the sheet references of all formulas of a
+// worksheet part. The worksheets are rewritten after saving as excelize
+// doesn't keep track of which cells contain formulas.
+func normalizeFormulas(worksheet []byte, sheets []string) []byte {
+	return formulaElement.ReplaceAllFunc(worksheet, func(element []byte) []byte {
+		parts := formulaElement.FindSubmatch(element)
+		formula := html.UnescapeString(string(parts[2]))
+		normalized := normalizeSheetReferences(formula, sheets)
+		if normalized == formula {
+			return element
+		}
+		slog.Debug("Normalized formula", "old", formula, "new", normalized)
+		var buf bytes.Buffer
+		xml.EscapeText(&buf, []byte(normalized))
+		return []byte("<f" + string(parts[1]) + ">" + buf.String() + "</f>")
+	})
+}
+
+// setFullCalcOnLoad makes the workbook in the saved package recalculate all
+// formulas when it is opened, keeping the other calculation properties.
+// excelize offers no way to set this.
+func setFullCalcOnLoad(workbook []byte) []byte {
+	if calcPrTag.Match(workbook) {
+		return calcPrTag.ReplaceAllFunc(workbook, func(tag []byte) []byte {
+			parts := calcPrTag.FindSubmatch(tag)
+			attributes := fullCalcOnLoad.ReplaceAll(parts[1], nil)
+			return []byte("<calcPr" + string(attributes) + ` fullCalcOnLoad="1"` + string(parts[2]) + ">")
+		})
+	}
+	calcPr := []byte(`<calcPr fullCalcOnLoad="1"/>`)
+	// calcPr follows the defined names and precedes everything else
+	for _, next := range []string{"<oleSize", "<customWorkbookViews", "<pivotCaches", "<smartTagPr", "<smartTagTypes", "<webPublishing", "<fileRecoveryPr", "<webPublishObjects", "<extLst", "</workbook>"} {
+		if i := bytes.Index(workbook, []byte(next)); i >= 0 {
+			return append(workbook[:i:i], append(calcPr, workbook[i:]...)...)
+		}
+	}
+	return workbook
+}
+
+// rewritePackage copies the zip package in data passing the content of every
+// part through rewrite.
+func rewritePackage(data []byte, rewrite func(name string, content []byte) []byte) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range r.File {
+		src, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(src)
+		src.Close()
+		if err != nil {
+			return nil, err
+		}
+		content = rewrite(file.Name, content)
+		dst, err := w.Create(file.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := dst.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SaveWorkbook writes f to the output file in the configured compatibility
+// mode. Formulas always use quoted sheet references and the 1900 date system
+// the entries are read with. For LibreOffice, which by default keeps the
+// cached values of Excel files, the workbook is marked for recalculation.
+func SaveWorkbook(f *excelize.File, config Configuration) error {
+	f.UpdateLinkedValue()
+
+	props, err := f.GetWorkbookProps()
+	if err != nil {
+		return err
+	}
+	if props.Date1904 != nil && *props.Date1904 {
+		slog.Error("Workbook uses the 1904 date system, dates will be off by four years", "file", config.ExcelFileName)
+	} else {
+		date1904 := false
+		if err := f.SetWorkbookProps(&excelize.WorkbookPropsOptions{Date1904: &date1904}); err != nil {
+			return err
+		}
+	}
+
+	switch config.OutputCompatibility {
+	case "", COMPAT_EXCEL, COMPAT_LIBREOFFICE:
+	default:
+		return fmt.Errorf("unknown output compatibility %q", config.OutputCompatibility)
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return err
+	}
+	sheets := f.GetSheetList()
+	data, err := rewritePackage(buf.Bytes(), func(name string, content []byte) []byte {
+		switch {
+		case strings.HasPrefix(name, "xl/worksheets/") && strings.HasSuffix(name, ".xml"):
+			return normalizeFormulas(content, sheets)
+		case name == "xl/workbook.xml" && config.OutputCompatibility == COMPAT_LIBREOFFICE:
+			return setFullCalcOnLoad(content)
+		}
+		return content
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(config.OutputFile, data, 0644)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestNormalizeSheetReferences(t *testing.T) {
+	sheets := []string{"01", "02", "Gesamt"}
+	for formula, expected := range map[string]string{
+		"SUM(01!J7:J40)":          "SUM('01'!J7:J40)",
+		"$'01'.J45+$'02'.J45":     "'01'!J45+'02'!J45",
+		"SUM('01'!J7:J40)":        "SUM('01'!J7:J40)",
+		"Gesamt!F4+A1":            "Gesamt!F4+A1",
+		"SUM(A1:A3)*101!B2":       "SUM(A1:A3)*101!B2",
+		"IF(02!A1>0,02!A1,01!A1)": "IF('02'!A1>0,'02'!A1,'01'!A1)",
+	} {
+		if res := normalizeSheetReferences(formula, sheets); res != expected {
+			t.Errorf("normalizing %q: expected %q, got %q", formula, expected, res)
+		}
+	}
+}
+
+func TestSaveWorkbookLibreOffice(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetName("Sheet1", "01")
+	f.NewSheet("Gesamt")
+	f.SetCellValue("01", "J7", 8)
+	f.SetCellFormula("Gesamt", "F4", "SUM(01!J7:J40)")
+	f.SetCellFormula("Gesamt", "G4", "$'01'.J7")
+
+	config := Configuration{OutputFile: filepath.Join(t.TempDir(), "out.xlsx"), OutputCompatibility: COMPAT_LIBREOFFICE}
+	if err := SaveWorkbook(f, config); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := excelize.OpenFile(config.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer saved.Close()
+	if formula, _ := saved.GetCellFormula("Gesamt", "F4"); formula != "SUM('01'!J7:J40)" {
+		t.Errorf("unexpected formula in F4: %q", formula)
+	}
+	if formula, _ := saved.GetCellFormula("Gesamt", "G4"); formula != "'01'!J7" {
+		t.Errorf("unexpected formula in G4: %q", formula)
+	}
+	if props, err := saved.GetWorkbookProps(); err != nil || props.Date1904 == nil || *props.Date1904 {
+		t.Errorf("expected the 1900 date system to be set explicitly, got %+v (%v)", props, err)
+	}
+
+	r, err := zip.OpenReader(config.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for _, file := range r.File {
+		if file.Name != "xl/workbook.xml" {
+			continue
+		}
+		src, _ := file.Open()
+		workbook, _ := io.ReadAll(src)
+		src.Close()
+		if !strings.Contains(string(workbook), `<calcPr fullCalcOnLoad="1"/>`) {
+			t.Errorf("expected workbook to be recalculated on load: %s", workbook)
+		}
+	}
+}
+
+func TestSetFullCalcOnLoad(t *testing.T) {
+	for workbook, expected := range map[string]string{
+		`<definedNames/><calcPr calcId="191029" iterate="1" fullCalcOnLoad="0"/></workbook>`: `<definedNames/><calcPr calcId="191029" iterate="1" fullCalcOnLoad="1"/></workbook>`,
+		`<calcPr calcId="191029" refMode="R1C1"></calcPr>`:                                   `<calcPr calcId="191029" refMode="R1C1" fullCalcOnLoad="1"></calcPr>`,
+		`<definedNames/><extLst/></workbook>`:                                                `<definedNames/><calcPr fullCalcOnLoad="1"/><extLst/></workbook>`,
+	} {
+		if res := string(setFullCalcOnLoad([]byte(workbook))); res != expected {
+			t.Errorf("expected %s, got %s", expected, res)
+		}
+	}
+}
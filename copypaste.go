@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+)
+
+// retargetEntries returns copies of entries moved to date and sheetName.
+// Fields only describing the original workbook row are dropped.
+func retargetEntries(entries []RowEntry, date time.Time, sheetName string) []RowEntry {
+	date = toSheetDate(date)
+	res := make([]RowEntry, 0, len(entries))
+	for _, entry := range entries {
+		start, end := entry.StartClock(), entry.EndClock()
+		entry.Date = date
+		entry.Day = WEEKDAYS[int(date.Weekday())]
+		entry.SheetName = sheetName
+		entry.Start, entry.End = start.On(date), end.On(date)
+		entry.RowIndex = 0
+		entry.RawRow = nil
+		entry.Styles = nil
+		entry.Formulas = nil
+		res = append(res, entry)
+	}
+	return res
+}
+
+// startOfWeek returns the monday of the week containing date.
+func startOfWeek(date time.Time) time.Time {
+	return date.AddDate(0, 0, -helperMod(int(date.Weekday())-1, 7))
+}
+
+// previousWorkday returns the last day before date which is neither on a
+// weekend nor a holiday. If there is none within a year, e.g. as all
+// weekdays are configured as weekend, it returns the day before date.
+func previousWorkday(date time.Time, config Configuration) time.Time {
+	for day := date.AddDate(0, 0, -1); !day.Before(date.AddDate(-1, 0, 0)); day = day.AddDate(0, 0, -1) {
+		if !isDayOff(day, config) {
+			return day
+		}
+	}
+	return date.AddDate(0, 0, -1)
+}
+
+// dayEntries returns the entries of the given date or nil if its month is not
+// loaded or it is in another year than the workbook.
+func (e *EntryList) dayEntries(date time.Time) *[]RowEntry {
+	if year := e.Year(); year != 0 && date.Year() != year {
+		return nil
+	}
+	month := int(date.Month()) - 1
+	if month >= len(e.Entries) || len(e.Entries[month]) < date.Day() {
+		return nil
+	}
+	return &e.Entries[month][date.Day()-1]
+}
+
+// PasteEntries copies entries onto date, skipping absences and entries that
+// already exist there. It returns the number of pasted entries.
+func (e *EntryList) PasteEntries(entries []RowEntry, date time.Time) int {
+	day := e.dayEntries(date)
+	if day == nil {
+		return 0
+	}
+	return addEntriesToDay(day, retargetEntries(workEntries(entries), date, e.sheetNameForMonth(int(date.Month())-1)))
+}
+
+// CopyDays copies the entries of numDays days starting at from onto the same
+// number of days starting at to. It returns the number of pasted entries.
+func (e *EntryList) CopyDays(from, to time.Time, numDays int) int {
+	pasted := 0
+	for i := 0; i < numDays; i++ {
+		source := e.dayEntries(from.AddDate(0, 0, i))
+		if source == nil || len(*source) == 0 {
+			continue
+		}
+		pasted += e.PasteEntries(*source, to.AddDate(0, 0, i))
+	}
+	return pasted
+}
+
+// entriesToTSV formats entries as tab separated values for pasting into
+// other tools.
+func entriesToTSV(entries []RowEntry) string {
+	var b strings.Builder
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Date.Format("02.01.2006"),
+			entry.StartClock().String(),
+			entry.EndClock().String(),
+			formatHHMM(entry.Pause),
+			entry.ProjectNr,
+			entry.Project,
+			strings.ReplaceAll(entry.Description, "\t", " "),
+		)
+	}
+	return b.String()
+}
+
+// copyToSystemClipboard writes entries to the clipboard of the system.
+func copyToSystemClipboard(entries []RowEntry) error {
+	return clipboard.WriteAll(entriesToTSV(entries))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCopyDaysAcrossMonths(t *testing.T) {
+	entries := make([][][]RowEntry, 12)
+	entries[0] = make([][]RowEntry, 31)
+	entries[1] = make([][]RowEntry, 31)
+	entryList := EntryList{Entries: entries}
+
+	friday := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	entryList.Entries[0][30] = []RowEntry{{
+		SheetName: "01",
+		Date:      friday,
+		Day:       "Fr",
+		Start:     friday.Add(9 * time.Hour),
+		End:       friday.Add(17 * time.Hour),
+		RowIndex:  40,
+	}}
+
+	monday := time.Date(2025, time.February, 3, 0, 0, 0, 0, time.UTC)
+	if previous := previousWorkday(monday, Configuration{}); !previous.Equal(friday) {
+		t.Fatalf("expected previous workday to be %s, got %s", friday, previous)
+	}
+	everyDayOff := Configuration{WeekendDays: []string{"Mo", "Di", "Mi", "Do", "Fr", "Sa", "So"}}
+	if previous := previousWorkday(monday, everyDayOff); !previous.Equal(monday.AddDate(0, 0, -1)) {
+		t.Errorf("expected the day before without any workdays, got %s", previous)
+	}
+	if pasted := entryList.CopyDays(friday, monday, 1); pasted != 1 {
+		t.Fatalf("expected one pasted entry, got %d", pasted)
+	}
+
+	pasted := entryList.Entries[1][2][0]
+	if pasted.SheetName != "02" || pasted.Day != "Mo" || !pasted.Date.Equal(monday) {
+		t.Errorf("entry was not moved to target day: %+v", pasted)
+	}
+	if !pasted.Start.Equal(monday.Add(9*time.Hour)) || !pasted.End.Equal(monday.Add(17*time.Hour)) {
+		t.Errorf("wall clock times were not kept: %s - %s", pasted.Start, pasted.End)
+	}
+	if pasted.RowIndex != 0 {
+		t.Errorf("expected row index to be reset, got %d", pasted.RowIndex)
+	}
+
+	if pasted := entryList.CopyDays(friday, monday, 1); pasted != 0 {
+		t.Errorf("expected copying twice not to duplicate entries, got %d", pasted)
+	}
+}
+
+func TestPasteSkipsAbsences(t *testing.T) {
+	entryList := newBulkTestEntries()
+	date := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+	vacation := RowEntry{Date: date, Start: date, End: date, Vacation: 8 * time.Hour}
+	entryList.Entries[0][9] = []RowEntry{vacation}
+
+	if pasted := entryList.CopyDays(date, date.AddDate(0, 0, 3), 1); pasted != 0 {
+		t.Errorf("expected the vacation not to be copied, got %d entries", pasted)
+	}
+	work := entryList.Entries[0][5][0]
+	if pasted := entryList.PasteEntries([]RowEntry{vacation, work}, date.AddDate(0, 0, 3)); pasted != 1 {
+		t.Errorf("expected only the work entry to be pasted, got %d entries", pasted)
+	}
+	selection := NewSelection(date, 0)
+	if selected := entryList.SelectedEntries(selection); len(selected) != 0 {
+		t.Errorf("expected the vacation not to be selectable for bulk edits, got %d entries", len(selected))
+	}
+}
+
+func TestCopyLastWeekAcrossNewYear(t *testing.T) {
+	entries := make([][][]RowEntry, 12)
+	for i := range entries {
+		entries[i] = make([][]RowEntry, 31)
+	}
+	entryList := EntryList{Entries: entries}
+
+	// the week of 2025-12-29 reaches into 2026, the one before into 2025
+	monday := time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC)
+	entryList.Entries[11][28] = []RowEntry{{SheetName: "12", Date: monday, Day: "Mo", Start: monday.Add(9 * time.Hour), End: monday.Add(17 * time.Hour)}}
+	january := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	entryList.Entries[0][0] = []RowEntry{{SheetName: "01", Date: january, Day: "Mi", Start: january.Add(9 * time.Hour), End: january.Add(12 * time.Hour)}}
+
+	// copying 2025-12-29 to 2026-01-04 onto the next week must not touch january 2025
+	if pasted := entryList.CopyDays(monday, monday.AddDate(0, 0, 7), 7); pasted != 0 {
+		t.Errorf("expected nothing to be pasted into the next year, got %d", pasted)
+	}
+	if pasted := entryList.CopyDays(monday.AddDate(0, 0, 7), monday, 7); pasted != 0 {
+		t.Errorf("expected nothing to be copied from the next year, got %d", pasted)
+	}
+	if day := entryList.Entries[0][0]; len(day) != 1 || !day[0].End.Equal(january.Add(12*time.Hour)) {
+		t.Errorf("expected january 2025 to be unchanged, got %+v", day)
+	}
+	if day := entryList.dayEntries(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)); day != nil {
+		t.Errorf("expected no entries for a day of another year, got %+v", *day)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	ROW_ADDED    = "inserted"
+	ROW_REMOVED  = "removed"
+	ROW_MODIFIED = "modified"
+)
+
+type CellChange struct {
+	Column string
+	Old    string
+	New    string
+}
+
+// RowChange describes how a row of a sheet changes when saving. Row numbers
+// are 1-based, OldRow refers to the workbook before and NewRow to the
+// workbook after saving.
+type RowChange struct {
+	Sheet   string
+	Kind    string
+	OldRow  int
+	NewRow  int
+	Old     []string
+	New     []string
+	Changes []CellChange
+}
+
+func rowKey(row []string) string {
+	return strings.TrimRight(strings.Join(row, "\x1f"), "\x1f")
+}
+
+// diffRows aligns the rows of a sheet before and after saving using their
+// longest common subsequence.
+func diffRows(sheet string, before, after [][]string) []RowChange {
+	n, m := len(before), len(after)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if rowKey(before[i]) == rowKey(after[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var changes []RowChange
+	var removed, added []int
+	flush := func() {
+		paired := min(len(removed), len(added))
+		for k := 0; k < paired; k++ {
+			changes = append(changes, modifiedRow(sheet, removed[k], added[k], before[removed[k]], after[added[k]]))
+		}
+		for _, i := range removed[paired:] {
+			changes = append(changes, RowChange{Sheet: sheet, Kind: ROW_REMOVED, OldRow: i + 1, Old: before[i]})
+		}
+		for _, j := range added[paired:] {
+			changes = append(changes, RowChange{Sheet: sheet, Kind: ROW_ADDED, NewRow: j + 1, New: after[j]})
+		}
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && rowKey(before[i]) == rowKey(after[j]):
+			flush()
+			i, j = i+1, j+1
+		case j >= m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, i)
+			i += 1
+		default:
+			added = append(added, j)
+			j += 1
+		}
+	}
+	flush()
+	return changes
+}
+
+func modifiedRow(sheet string, i, j int, old, new []string) RowChange {
+	change := RowChange{Sheet: sheet, Kind: ROW_MODIFIED, OldRow: i + 1, NewRow: j + 1, Old: old, New: new}
+	for col := 0; col < max(len(old), len(new)); col++ {
+		var o, n string
+		if col < len(old) {
+			o = old[col]
+		}
+		if col < len(new) {
+			n = new[col]
+		}
+		if o != n {
+			name, _ := excelize.ColumnNumberToName(col + 1)
+			change.Changes = append(change.Changes, CellChange{Column: name, Old: o, New: n})
+		}
+	}
+	return change
+}
+
+// DiffWorkbooks compares the given sheets of two workbooks row by row.
+func DiffWorkbooks(before, after *excelize.File, sheets []string) ([]RowChange, error) {
+	var changes []RowChange
+	for _, sheet := range sheets {
+		beforeRows, err := before.GetRows(sheet, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, err
+		}
+		afterRows, err := after.GetRows(sheet, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, diffRows(sheet, beforeRows, afterRows)...)
+	}
+	return changes, nil
+}
+
+// copyWorkbook returns an independent copy of f.
+func copyWorkbook(f *excelize.File) (*excelize.File, error) {
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return excelize.OpenReader(buf, excelize.Options{RawCellValue: true})
+}
+
+// PreviewRowEntries returns the changes WriteRowEntries would make to the
+// workbook without touching it.
+func PreviewRowEntries(entries map[string][][]RowEntry, config Configuration) ([]RowChange, error) {
+	preview, err := copyWorkbook(config.ExcelFile)
+	if err != nil {
+		return nil, err
+	}
+	defer preview.Close()
+	applyRowEntries(preview, entries, config)
+	if _, err := RepairSummarySheet(preview, entries, config); err != nil {
+		return nil, err
+	}
+
+	sheets := make([]string, 0, len(entries))
+	for sheet := range entries {
+		sheets = append(sheets, sheet)
+	}
+	sort.Strings(sheets)
+	return DiffWorkbooks(config.ExcelFile, preview, sheets)
+}
+
+// FormatDiff renders changes as one line per row and cell.
+func FormatDiff(changes []RowChange) string {
+	if len(changes) == 0 {
+		return tr("No changes.") + "\n"
+	}
+	s := ""
+	for _, change := range changes {
+		switch change.Kind {
+		case ROW_ADDED:
+			s += trf("%s: + row %d inserted: %s", change.Sheet, change.NewRow, strings.Join(change.New, " | ")) + "\n"
+		case ROW_REMOVED:
+			s += trf("%s: - row %d removed: %s", change.Sheet, change.OldRow, strings.Join(change.Old, " | ")) + "\n"
+		case ROW_MODIFIED:
+			s += trf("%s: ~ row %d modified (now row %d):", change.Sheet, change.OldRow, change.NewRow) + "\n"
+			for _, cell := range change.Changes {
+				s += fmt.Sprintf("      %s: %q → %q\n", cell.Column, cell.Old, cell.New)
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestDiffRows(t *testing.T) {
+	before := [][]string{
+		{"45663", "Mo", "0.33", "0.5"},
+		{"45664", "Di", "0.33", "0.5"},
+		{"45665", "Mi"},
+		{"45666", "Do", "0.33", "0.5"},
+	}
+	after := [][]string{
+		{"45663", "Mo", "0.33", "0.5"},
+		{"45664", "Di", "0.33", "0.4"},
+		{"45664", "Di", "0.4", "0.5"},
+		{"45666", "Do", "0.33", "0.5"},
+	}
+
+	changes := diffRows("01", before, after)
+	if len(changes) != 2 {
+		t.Fatalf("expected two changed rows, got %+v", changes)
+	}
+	if changes[0].Kind != ROW_MODIFIED || changes[0].OldRow != 2 || len(changes[0].Changes) != 1 || changes[0].Changes[0].Column != "D" {
+		t.Errorf("expected row 2 to be modified in column D, got %+v", changes[0])
+	}
+	if changes[1].Kind != ROW_MODIFIED || changes[1].OldRow != 3 || changes[1].NewRow != 3 {
+		t.Errorf("expected row 3 to be replaced, got %+v", changes[1])
+	}
+
+	changes = diffRows("01", before, before[:3])
+	if len(changes) != 1 || changes[0].Kind != ROW_REMOVED || changes[0].OldRow != 4 {
+		t.Errorf("expected row 4 to be removed, got %+v", changes)
+	}
+	changes = diffRows("01", before[:3], before)
+	if len(changes) != 1 || changes[0].Kind != ROW_ADDED || changes[0].NewRow != 4 {
+		t.Errorf("expected row 4 to be inserted, got %+v", changes)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// markModified remembers that the entries of all days between from and to
+// (both inclusive) differ from the saved workbook.
+func (m *Model) markModified(from, to time.Time) {
+	from, to = toSheetDate(from), toSheetDate(to)
+	if to.Before(from) {
+		from, to = to, from
+	}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if day := m.entryList.dayEntries(date); day != nil {
+			m.modified[date] = true
+			if err := m.journal.Record(date, *day); err != nil {
+				slog.Error("Could not write change to journal", "date", date, "error", err)
+			}
+		}
+	}
+}
+
+// dayDate returns the given day in the year shown by the date picker.
+func (m Model) dayDate(month time.Month, day int) time.Time {
+	return time.Date(m.datepicker.currentDay.Year(), month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (m Model) isModified(date time.Time) bool {
+	return m.modified[toSheetDate(date)]
+}
+
+func (m Model) isMonthModified(month time.Month) bool {
+	for date := range m.modified {
+		if date.Month() == month {
+			return true
+		}
+	}
+	return false
+}
+
+// save writes all entries to the output file and resets the modified state.
+// It reports whether the entries were written; if not, the modified state
+// and the journal are kept.
+func (m *Model) save() bool {
+	if m.config.ReadOnly {
+		m.debugMessage = tr("Workbook was opened read-only, cannot save!")
+		return false
+	}
+	m.checkWorkbook()
+	if len(m.conflicts) > 0 {
+		m.debugMessage = tr("Workbook was changed on disk, resolve the conflicts before saving!")
+		return false
+	}
+
+	if err := WriteRowEntries(m.entryList.Sheets(), m.config); err != nil {
+		slog.Error("Could not save entries", "file", m.config.OutputFile, "error", err)
+		m.debugMessage = tr("Could not save:") + " " + err.Error()
+		return false
+	}
+	if sameFile(m.config.OutputFile, m.config.ExcelFileName) {
+		// don't mistake our own changes for external ones
+		m.base = copyEntries(m.entryList.Entries)
+		if state, err := readFileState(m.config.ExcelFileName); err == nil {
+			m.fileState = state
+		}
+	}
+	clear(m.modified)
+	if err := m.journal.Clear(); err != nil {
+		slog.Error("Could not clear journal", "error", err)
+	}
+	slog.Info("Saved entries", "file", m.config.OutputFile)
+	return true
+}
+
+// previewSave shows the changes saving would make to the workbook and asks
+// for confirmation.
+func (m *Model) previewSave() {
+	changes, err := PreviewRowEntries(m.entryList.Sheets(), m.config)
+	if err != nil {
+		slog.Error("Could not preview changes", "error", err)
+		m.debugMessage = tr("Could not preview changes:") + " " + err.Error()
+		return
+	}
+	m.savePreview = FormatDiff(changes)
+	m.savePreviewActive = true
+}
+
+func (m Model) updateSavePreview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.ConfirmSave), key.Matches(msg, keys.Edit):
+		m.savePreviewActive = false
+		if m.save() {
+			m.debugMessage = tr("Saved to") + " " + m.config.OutputFile
+		}
+	case key.Matches(msg, keys.ConfirmCancel), key.Matches(msg, keys.CancelEdit):
+		m.savePreviewActive = false
+		m.debugMessage = tr("Save aborted")
+	case key.Matches(msg, keys.Quit):
+		return m, m.quit()
+	}
+	return m, nil
+}
+
+func (m Model) viewSavePreview() string {
+	s := m.styles["tableHeader"].Render(" " + tr("Changes to") + " " + m.config.OutputFile)
+	s += "\n" + m.savePreview + "\n"
+	s += viewChoices(keys.ConfirmSave, keys.ConfirmCancel) + "\n"
+	if m.quitConfirmActive {
+		s += "\n" + m.viewQuitConfirm()
+	}
+	return s
+}
+
+// quit leaves the program, asking what to do with unsaved changes first. If
+// they are saved on quit and that fails, the editor stays open showing why.
+func (m *Model) quit() tea.Cmd {
+	if len(m.modified) == 0 {
+		m.journal.Remove()
+		return tea.Quit
+	}
+	if m.config.SaveOnQuit {
+		if !m.save() {
+			return nil
+		}
+		m.journal.Remove()
+		return tea.Quit
+	}
+	m.quitConfirmActive = true
+	return nil
+}
+
+func (m Model) updateQuitConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.ConfirmSave):
+		m.quitConfirmActive = false
+		if !m.save() {
+			break
+		}
+		m.journal.Remove()
+		return m, tea.Quit
+	case key.Matches(msg, keys.ConfirmDiscard):
+		m.journal.Remove()
+		return m, tea.Quit
+	case key.Matches(msg, keys.ConfirmCancel), key.Matches(msg, keys.CancelEdit):
+		m.quitConfirmActive = false
+	}
+	return m, nil
+}
+
+func (m Model) viewQuitConfirm() string {
+	return m.styles["inputFieldErr"].Render(
+		trf("Unsaved changes on %d days!", len(m.modified)) + " " + viewChoices(keys.ConfirmSave, keys.ConfirmDiscard, keys.ConfirmCancel),
+	)
+}
+
+// viewCalendar renders the month of the current day, marking modified days
+// with a '*'.
+func (m Model) viewCalendar() string {
+	current := m.datepicker.currentDay
+	first := current.AddDate(0, 0, 1-current.Day())
+
+	title := formatDate(first, "January 2006")
+	if m.isMonthModified(current.Month()) {
+		title += " *"
+	}
+	s := fmt.Sprintf("%-27s\n", title)
+	for i := range locale.WeekdaysShort {
+		s += fmt.Sprintf("%-4.3s", locale.WeekdaysShort[(i+1)%7])
+	}
+	s += "\n" + strings.Repeat("    ", helperMod(int(first.Weekday())-1, 7))
+
+	for date := first; date.Month() == first.Month(); date = date.AddDate(0, 0, 1) {
+		marker := " "
+		if m.isModified(date) {
+			marker = "*"
+		}
+		cell := fmt.Sprintf("%2d%s", date.Day(), marker)
+		if date.Day() == current.Day() {
+			cell = m.styles["selectedEntry"].Render(cell)
+		}
+		s += cell + " "
+		if date.Weekday() == time.Sunday {
+			s += "\n"
+		}
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How durations are displayed, see formatDuration.
+const (
+	DURATION_HHMM       = "hhmm"       // hours and minutes, 7:30
+	DURATION_DECIMAL    = "decimal"    // decimal hours, 7.50
+	DURATION_INDUSTRIAL = "industrial" // industrial minutes, hundredths of an hour, 750
+)
+
+var durationModes = []string{DURATION_HHMM, DURATION_DECIMAL, DURATION_INDUSTRIAL}
+
+var durationModeLabels = map[string]string{
+	DURATION_HHMM:       "hours and minutes",
+	DURATION_DECIMAL:    "decimal hours",
+	DURATION_INDUSTRIAL: "industrial minutes",
+}
+
+// durationMode is how durations are displayed, see SetDurationMode.
+var durationMode = DURATION_HHMM
+
+// SetDurationMode switches how durations are displayed in the editor and the
+// exports. Unknown modes fall back to hours and minutes.
+func SetDurationMode(mode string) {
+	if !isDurationMode(mode) {
+		mode = DURATION_HHMM
+	}
+	durationMode = mode
+}
+
+func isDurationMode(mode string) bool {
+	for _, m := range durationModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// nextDurationMode returns the display mode following the current one.
+func nextDurationMode() string {
+	for i, m := range durationModes {
+		if m == durationMode {
+			return durationModes[(i+1)%len(durationModes)]
+		}
+	}
+	return DURATION_HHMM
+}
+
+// formatDuration formats d in the current display mode, rounded to the
+// minute.
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	d = d.Round(time.Minute)
+	switch durationMode {
+	case DURATION_DECIMAL:
+		return fmt.Sprintf("%s%.2f", sign, d.Hours())
+	case DURATION_INDUSTRIAL:
+		return fmt.Sprintf("%s%d", sign, int(math.Round(d.Hours()*100)))
+	}
+	return sign + formatHHMM(d)
+}
+
+// formatHHMM formats d as hours and minutes regardless of the display mode,
+// e.g. for exports read by other tools.
+func formatHHMM(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%s%d:%02d", sign, int(d.Hours()), int(d.Minutes())%60)
+}
+
+// parseDuration parses a duration entered in the current display mode, e.g.
+// 0:30, 0.5 or 50 for half an hour. Go durations like 30m are accepted in
+// every mode.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	invalid := fmt.Errorf("invalid duration %q, expected %s", s, formatDuration(30*time.Minute))
+	switch durationMode {
+	case DURATION_DECIMAL:
+		hours, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+		if err != nil || hours < 0 {
+			return 0, invalid
+		}
+		return time.Duration(math.Round(hours*60)) * time.Minute, nil
+	case DURATION_INDUSTRIAL:
+		hundredths, err := strconv.Atoi(s)
+		if err != nil || hundredths < 0 {
+			return 0, invalid
+		}
+		return time.Duration(math.Round(float64(hundredths)*0.6)) * time.Minute, nil
+	}
+	hours, minutes, found := strings.Cut(s, ":")
+	h, err := strconv.Atoi(hours)
+	if !found || err != nil || h < 0 || len(minutes) != 2 {
+		return 0, invalid
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, invalid
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// parseSignedDuration parses a duration like parseDuration which may start
+// with a sign, e.g. -0:15 or +1:30.
+func parseSignedDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if rest, negative := strings.CutPrefix(s, "-"); negative {
+		d, err := parseDuration(rest)
+		return -d, err
+	}
+	return parseDuration(strings.TrimPrefix(s, "+"))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	defer SetDurationMode(durationMode)
+
+	d := 7*time.Hour + 30*time.Minute
+	for mode, expected := range map[string]string{
+		DURATION_HHMM:       "7:30",
+		DURATION_DECIMAL:    "7.50",
+		DURATION_INDUSTRIAL: "750",
+	} {
+		SetDurationMode(mode)
+		if s := formatDuration(d); s != expected {
+			t.Errorf("mode %s: expected %q, got %q", mode, expected, s)
+		}
+	}
+
+	SetDurationMode(DURATION_HHMM)
+	if s := formatDuration(-45 * time.Minute); s != "-0:45" {
+		t.Errorf("unexpected negative duration %q", s)
+	}
+	if next := nextDurationMode(); next != DURATION_DECIMAL {
+		t.Errorf("expected decimal hours after hh:mm, got %s", next)
+	}
+	SetDurationMode("minutes")
+	if durationMode != DURATION_HHMM {
+		t.Errorf("expected unknown modes to fall back to hh:mm, got %s", durationMode)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	defer SetDurationMode(durationMode)
+
+	for mode, input := range map[string]string{
+		DURATION_HHMM:       "0:45",
+		DURATION_DECIMAL:    "0,75",
+		DURATION_INDUSTRIAL: "75",
+	} {
+		SetDurationMode(mode)
+		if d, err := parseDuration(input); err != nil || d != 45*time.Minute {
+			t.Errorf("mode %s: expected %q to be 45m, got %s (%v)", mode, input, d, err)
+		}
+		if d, err := parseDuration("45m"); err != nil || d != 45*time.Minute {
+			t.Errorf("mode %s: expected Go durations to be accepted, got %s (%v)", mode, d, err)
+		}
+		// the placeholder of the pause input is parsed back
+		if d, err := parseDuration(formatDuration(30 * time.Minute)); err != nil || d != 30*time.Minute {
+			t.Errorf("mode %s: expected the formatted pause to be parsed back, got %s (%v)", mode, d, err)
+		}
+	}
+
+	SetDurationMode(DURATION_HHMM)
+	for _, input := range []string{"45", "0:5", "abc"} {
+		if _, err := parseDuration(input); err == nil {
+			t.Errorf("expected %q to be invalid", input)
+		}
+	}
+	for input, expected := range map[string]time.Duration{"-0:15": -15 * time.Minute, "+1:30": 90 * time.Minute, "1:30": 90 * time.Minute, "-15m": -15 * time.Minute} {
+		if d, err := parseSignedDuration(input); err != nil || d != expected {
+			t.Errorf("expected %q to be %s, got %s (%v)", input, expected, d, err)
+		}
+	}
+
+	// exports don't depend on the display mode
+	SetDurationMode(DURATION_INDUSTRIAL)
+	date := time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)
+	tsv := entriesToTSV([]RowEntry{{Date: date, Start: date.Add(8 * time.Hour), End: date.Add(12 * time.Hour), Pause: 30 * time.Minute}})
+	if tsv != "07.01.2025\t08:00\t12:00\t0:30\t\t\t\n" {
+		t.Errorf("unexpected TSV %q", tsv)
+	}
+	var html strings.Builder
+	if err := billingTemplate.Execute(&html, BillingStatement{Duration: 90 * time.Minute}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html.String(), "1:30 =") {
+		t.Errorf("expected the statement to show 1:30 hours, got\n%s", html.String())
+	}
+}
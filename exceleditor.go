@@ -7,6 +7,7 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -28,38 +29,105 @@ type RowEntry struct {
 	Vacation    time.Duration
 	Sickness    time.Duration
 	Note        string
-	RawRow      []string
-	Styles      []excelize.Style
-	Formulas    []string
+	Breaks      []Break          // break intervals, recorded in the note
+	RawRow      []string         `json:"-"`
+	Styles      []excelize.Style `json:"-"`
+	Formulas    []string         `json:"-"`
 }
 
 type Configuration struct {
-	ExcelFileName       string
-	ExcelFile           *excelize.File
+	ExcelFileName       string         `json:"-"`
+	ExcelFile           *excelize.File `json:"-"`
 	COL_ID_DATE         int
 	COL_ID_HOURS_START  int
 	COL_ID_HOURS_END    int
 	COL_ID_HOURS_PAUSE  int
 	ROW_ID_ENTRY_START  int
-	OutputFile          string
+	OutputFile          string `json:"-"`
 	ProjectNumbersSheet string
+
+	// Billing
+	DefaultHourlyRate      float64
+	HourlyRates            map[string]float64 // project number -> rate, overrides the project sheet
+	Currency               string
+	BillingRoundingMinutes int    // billable time of each entry is rounded to this increment (0 = exact)
+	BillingRoundingMode    string // "up", "down" or "nearest"
+
+	// Rounding of entry times. A policy applying to reports overrides the
+	// billing increment, one applying only to entries doesn't.
+	Rounding        RoundingPolicy
+	ProjectRounding map[string]RoundingPolicy // project number -> policy, overrides Rounding
+
+	Templates []EntryTemplate
+	Holidays  []string // dates as "2006-01-02"
+
+	WeekendDays      []string // workbook weekday abbreviations of non-working days, defaults to Sa and So
+	WeekendSurcharge float64  // surcharge on work on weekends and holidays in percent
+
+	UseSystemClipboard bool // additionally copy yanked entries to the system clipboard as TSV
+
+	SaveOnQuit bool // save unsaved changes on quit instead of asking
+
+	DisableMouse bool // leave the mouse to the terminal, e.g. to select text
+
+	// Summary sheet totalling the monthly sheets, "" disables it. Only empty,
+	// broken or outdated totals are regenerated, custom formulas are kept.
+	SummarySheet    string
+	SummaryFirstRow int               // row of January, the other months follow below
+	SummaryColumns  map[string]string // summary column -> totalled column of the monthly sheets
+
+	OutputCompatibility string // application the saved workbook is opened with, "excel" or "libreoffice"
+	OvernightMode       string // how entries ending on the following day are written, "mark" or "split"
+
+	Language        string // language of the user interface, "en" or "de"
+	DurationDisplay string // how durations are shown, "hhmm", "decimal" or "industrial"
+
+	KeyPreset string              // "default", "vim" or "arrows"
+	Keys      map[string][]string // action -> keys, e.g. "PrevDay": ["pgup"], overriding the preset
+
+	Theme     string                   // ID of a tint of the bubbletint registry
+	StyleFile string                   // JSON file overriding individual styles
+	Styles    map[string]StyleOverride // style name -> override, extended by StyleFile
+
+	ReplayJournal bool `json:"-"` // restore unsaved changes of a previous run from the journal
+	ReadOnly      bool `json:"-"` // the workbook is locked by someone else, saving is disabled
 }
 
 type Project struct {
 	ID       string
 	Name     string
 	Customer string
+	Rate     float64 // hourly rate from the optional fourth column of the project sheet
 }
 
 var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
 
+// excelDateToDate returns the sheet date of an Excel serial date. A time
+// of day given as fraction is ignored.
 func excelDateToDate(excelDate string) time.Time {
-	var days, _ = strconv.Atoi(excelDate)
-	return excelEpoch.Add(time.Second * time.Duration(days*86400))
+	var days, _ = strconv.ParseFloat(excelDate, 64)
+	return excelEpoch.AddDate(0, 0, int(math.Floor(days)))
+}
+
+// toSheetDate returns the date of t at midnight UTC, which is how dates read
+// from the workbook are represented.
+func toSheetDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 }
+
 func dateToExcelDate(date time.Time) string {
-	dur := date.Sub(excelEpoch)
-	return fmt.Sprint(math.Floor(dur.Abs().Hours()))
+	dur := toSheetDate(date).Sub(excelEpoch)
+	return fmt.Sprint(int(math.Round(dur.Hours() / 24)))
+}
+
+// Duration returns the worked time of the entry without its pause. This is
+// the time which actually elapsed, so it differs from the difference of the
+// wall clock times if DST started or ended in between.
+func (r RowEntry) Duration() time.Duration {
+	if !r.HasEnd() {
+		return 0
+	}
+	return inWorkLocation(r.End).Sub(inWorkLocation(r.Start)) - r.Pause
 }
 
 func timeToFloat(time time.Time) float64 {
@@ -92,13 +160,13 @@ func ReadEntryFromRowAt(currentRow []string, sheet string, rowIdx, colIdx int) (
 	if res.Day == "" {
 		slog.Error("No day provided", "day", res.Day)
 	}
-	if res.Day == "Sa" || res.Day == "So" {
-		return res, nil
-	}
-
 	//res.Start, err = time.Parse(time.TimeOnly, currentRow[colIdx+2])
 	res.Start = calcTimeFromFloat(res.Date, currentRow[colIdx+2])
 	res.End = calcTimeFromFloat(res.Date, currentRow[colIdx+3])
+	if res.End.Before(res.Start) && currentRow[colIdx+3] != "" {
+		// the entry ends on the following day, an empty end stays missing
+		res.End = res.End.Add(24 * time.Hour)
+	}
 	res.Pause = calcDurationFromFloat(currentRow[colIdx+4])
 
 	res.ProjectNr = currentRow[colIdx+5]
@@ -118,6 +186,7 @@ func ReadEntryFromRowAt(currentRow []string, sheet string, rowIdx, colIdx int) (
 		return res, nil
 	}
 	res.Note = currentRow[colIdx+12]
+	res.Breaks = breaksFromNote(res.Note)
 
 	// cellID := fmt.Sprintf("%c%d", rune(int(colIdx)+internalOffset), row)
 	// res.Date = time.Parse("02/01/2016", )
@@ -139,22 +208,29 @@ func calcTimeFromFloat(date time.Time, f string) time.Time {
 	tmp, err := strconv.ParseFloat(f, 64)
 	if err != nil {
 		slog.Error("Failed to parse time from float, falling back to string parsing... ", "string", f)
-
+		clock, err := ParseClock(f)
+		if err != nil {
+			slog.Error("Failed to parse time", "string", f, "error", err)
+		}
+		return clock.On(date)
 	}
-	var hours = int(tmp * 24)
-	var minutes = (math.Round((tmp - float64(hours)/24) * 24 * 60 * 100)) / 100
-	return date.Add(time.Duration(int(hours))*time.Hour + time.Duration(int(minutes))*time.Minute)
+	return clockFromFraction(tmp).On(date)
 }
 
 func calcDurationFromFloat(f string) time.Duration {
 	if f == "" {
 		return time.Duration(0)
 	}
-	tmp, err := strconv.ParseFloat(f, 32)
+	tmp, err := strconv.ParseFloat(f, 64)
 	if err != nil {
 		slog.Error("Failed to parse time from float, falling back to string parsing... ", "string", f)
+		clock, err := ParseClock(f)
+		if err != nil {
+			slog.Error("Failed to parse duration", "string", f, "error", err)
+		}
+		return time.Duration(clock) * time.Minute
 	}
-	return time.Duration(int(tmp*24))*time.Hour + time.Duration(int(math.Mod(tmp*24, 1.0)*60))*time.Minute
+	return time.Duration(math.Round(tmp*MINUTES_PER_DAY)) * time.Minute
 }
 
 func ReturnAll(config Configuration) [][][]RowEntry {
@@ -197,7 +273,8 @@ func ReturnMonth(month string, config Configuration) [][]RowEntry {
 			slog.Debug("Error while parsing row: ", "row", rowEntry, "error", err)
 			continue
 		}
-		if !rowEntry.Start.Equal(rowEntry.End) {
+		// rows of vacation and sick days have no times
+		if !rowEntry.Start.Equal(rowEntry.End) || rowEntry.Vacation > 0 || rowEntry.Sickness > 0 {
 			rowEntries = append(rowEntries, rowEntry)
 		}
 	}
@@ -242,28 +319,38 @@ func WriteRowEntry(f *excelize.File, sheetname string, row int, entry RowEntry)
 
 	// f.SetCellValue(sheetname, fmt.Sprintf("B%d", row), entry.Day)
 	// f.SetCellValue(sheetname, fmt.Sprintf("B%d", row), "Mo")
-	f.SetCellValue(sheetname, fmt.Sprintf("C%d", row), entry.Start.Format("15:04"))
+	f.SetCellFloat(sheetname, fmt.Sprintf("C%d", row), entry.StartClock().Fraction(), -1, 64)
 	// f.SetCellValue(sheetname, fmt.Sprintf("C%d", row), entry.Start.Format("15:04")+":00")
 	// f.SetCellValue(sheetname, fmt.Sprintf("C%d", row), timeToFloat(entry.Start))
 	// f.SetCellFloat(sheetname, fmt.Sprintf("D%d", row), timeToFloat(entry.End), 8, 64)
-	f.SetCellValue(sheetname, fmt.Sprintf("D%d", row), entry.End.Format("15:04"))
+	if entry.HasEnd() {
+		f.SetCellFloat(sheetname, fmt.Sprintf("D%d", row), entry.EndClock().Fraction(), -1, 64)
+	} else {
+		f.SetCellValue(sheetname, fmt.Sprintf("D%d", row), nil)
+	}
 	// f.SetCellValue(sheetname, fmt.Sprintf("D%d", row), entry.End.Format("15:04")+":00")
 	if entry.Pause > time.Duration(0) {
-		f.SetCellValue(sheetname, fmt.Sprintf("E%d", row), entry.Pause)
+		f.SetCellFloat(sheetname, fmt.Sprintf("E%d", row), entry.Pause.Minutes()/MINUTES_PER_DAY, -1, 64)
 	} else {
 		f.SetCellValue(sheetname, fmt.Sprintf("E%d", row), nil)
 	}
 	f.SetCellValue(sheetname, fmt.Sprintf("F%d", row), entry.ProjectNr)
 	f.SetCellValue(sheetname, fmt.Sprintf("I%d", row), entry.Description)
+	if entry.Note != "" || (len(entry.RawRow) > 12 && entry.RawRow[12] != "") {
+		f.SetCellValue(sheetname, fmt.Sprintf("M%d", row), entry.Note)
+	}
 	// d := entry.End.Sub(entry.Start) - entry.Pause
 	// hour := int(d.Hours())
 	// minute := int(d.Minutes()) % 60
 	// f.SetCellFloat(sheetname, fmt.Sprintf("J%d", row), float64(hour)+float64(minute)/60.0, 2, 64)
 }
 
-func WriteRowEntries(entries map[string][][]RowEntry, config Configuration) {
-
-	f := config.ExcelFile
+// applyRowEntries writes the entries of every month into its sheet of f,
+// inserting and removing rows as needed.
+func applyRowEntries(f *excelize.File, entries map[string][][]RowEntry, config Configuration) {
+	if config.OvernightMode == OVERNIGHT_SPLIT {
+		entries = splitOvernightEntries(entries)
+	}
 	for sheetname, month := range entries {
 		slog.Info("Writing entries for month", "month", sheetname, "#days", len(month))
 		var currentRowIndex = config.ROW_ID_ENTRY_START
@@ -326,28 +413,23 @@ func WriteRowEntries(entries map[string][][]RowEntry, config Configuration) {
 		// 	slog.Debug("Successfully unset conditional formatting", "dimension", dimension)
 		// }
 	}
+}
 
-	// indx, _ := f.GetSheetIndex("Gesamt")
-
-	// Fix formulas in overview
-	// for row := 4; row <= 15; row++ {
-	// 	for _, col := range []string{"F", "G", "H"} {
-	// 		sheetNum := row - 3
-	// 		cell := fmt.Sprintf("%s%d", col, row)
-	// 		formula, _ := f.GetCellFormula("Gesamt", cell)
-	// 		newFormula := strings.ReplaceAll(formula, fmt.Sprintf("%02d!", sheetNum), fmt.Sprintf("$'%02d'.", sheetNum))
-	// 		// newFormula = newFormula[:len(newFormula)-1]
-	// 		// f.SetCellValue("Gesamt", cell, "")
-	// 		f.SetCellFormula("Gesamt", cell, newFormula)
-	// 		res, _ := f.GetCellFormula("Gesamt", cell)
-	// 		slog.Info("Wrote formula", "cell", cell, "old", formula, "new", res)
-	// 		f.UpdateLinkedValue()
-	// 	}
-	// }
+// WriteRowEntries writes the entries to the workbook and saves it to the
+// output file.
+func WriteRowEntries(entries map[string][][]RowEntry, config Configuration) error {
+
+	f := config.ExcelFile
+	applyRowEntries(f, entries, config)
 
-	f.UpdateLinkedValue()
-	// f.SetActiveSheet(indx)
-	f.SaveAs(config.OutputFile, excelize.Options{RawCellValue: true})
+	if _, err := RepairSummarySheet(f, entries, config); err != nil {
+		return fmt.Errorf("could not update summary sheet %q: %w", config.SummarySheet, err)
+	}
+
+	if err := SaveWorkbook(f, config); err != nil {
+		return fmt.Errorf("could not save workbook %q: %w", config.OutputFile, err)
+	}
+	return nil
 }
 
 func GetProjectNumbers(config Configuration) (map[string]Project, map[string]Project, map[string]Project) {
@@ -369,9 +451,17 @@ func GetProjectNumbers(config Configuration) (map[string]Project, map[string]Pro
 			// slog.Info("Skipping entry in project numbers", "row", row)
 			continue
 		}
-		projectNumbers[row[0]] = Project{ID: row[0], Name: row[1], Customer: row[2]}
-		projectNames[row[1]] = Project{ID: row[0], Name: row[1], Customer: row[2]}
-		projectCustomers[row[2]] = Project{ID: row[0], Name: row[1], Customer: row[2]}
+		project := Project{ID: row[0], Name: row[1], Customer: row[2]}
+		if len(row) > 3 && row[3] != "" {
+			rate, err := strconv.ParseFloat(strings.ReplaceAll(row[3], ",", "."), 64)
+			if err != nil {
+				slog.Warn("Could not parse hourly rate of project", "project", row[0], "rate", row[3])
+			}
+			project.Rate = rate
+		}
+		projectNumbers[row[0]] = project
+		projectNames[row[1]] = project
+		projectCustomers[row[2]] = project
 	}
 
 	return projectNumbers, projectNames, projectCustomers
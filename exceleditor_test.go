@@ -96,7 +96,9 @@ func TestWriteFile(t *testing.T) {
 		{Day: "Mi", Date: date, Start: time.Now(), End: time.Now().Add(time.Duration(1) * time.Hour), Description: "Test entry"},
 		{Day: "Mi", Date: date, Start: time.Now().Add(time.Duration(1) * time.Hour), End: time.Now().Add(time.Duration(2) * time.Hour), Description: "Test entry2"},
 	}
-	WriteRowEntries(sheets, testConfig)
+	if err := WriteRowEntries(sheets, testConfig); err != nil {
+		t.Fatal(err)
+	}
 
 	return
 }
@@ -127,7 +129,9 @@ func TestWriteIdenticalFile(t *testing.T) {
 		}
 	}
 
-	WriteRowEntries(sheets, testConfig)
+	if err := WriteRowEntries(sheets, testConfig); err != nil {
+		t.Fatal(err)
+	}
 
 	return
 }
@@ -149,3 +153,17 @@ func TestGetProjectNumbers(t *testing.T) {
 	res, _, _ := GetProjectNumbers(testConfig)
 	fmt.Println("Read project numbers:\n", res)
 }
+
+func TestReadWeekendEntry(t *testing.T) {
+	row := []string{"45661", "Sa", "0.375", "0.5", "", "2024-1310", "Portal", "ACME", "Release", "3"}
+	entry, err := ReadEntryFromRow(row, "01", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Duration() != 3*time.Hour {
+		t.Errorf("expected 3h of weekend work, got %s", entry.Duration())
+	}
+	if !isDayOff(entry.Date, Configuration{}) {
+		t.Errorf("expected %s to be a day off", entry.Date)
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const PROMPT_GOTO = "goto"
+
+var (
+	absoluteDatePattern = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{2}|\d{4})?$`)
+	relativeDatePattern = regexp.MustCompile(`^([+-])(\d+)\s*([dwm]?)$`)
+)
+
+// Words of the date expressions, in English and German.
+var (
+	todayWords     = []string{"today", "heute"}
+	yesterdayWords = []string{"yesterday", "gestern"}
+	tomorrowWords  = []string{"tomorrow", "morgen"}
+	lastWords      = []string{"last", "prev", "letzte", "letzten", "letzter", "vorige", "vorigen"}
+	nextWords      = []string{"next", "nächste", "nächsten", "nächster"}
+	weekWords      = []string{"week", "woche"}
+	monthWords     = []string{"month", "monat"}
+)
+
+func isOneOf(word string, words []string) bool {
+	for _, w := range words {
+		if word == w {
+			return true
+		}
+	}
+	return false
+}
+
+// matchName returns the index of the only name starting with word, which
+// needs at least two letters. Names are compared case insensitively.
+func matchName(word string, names ...[]string) (int, bool) {
+	if len([]rune(word)) < 2 {
+		return 0, false
+	}
+	match := -1
+	for _, list := range names {
+		for i, name := range list {
+			if !strings.HasPrefix(strings.ToLower(name), word) {
+				continue
+			}
+			if match >= 0 && match != i {
+				return 0, false
+			}
+			match = i
+		}
+	}
+	return match, match >= 0
+}
+
+// addMonths moves date by months, using the last day of the month if it is
+// shorter, e.g. from 31.01. to 28.02.
+func addMonths(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	day := min(date.Day(), first.AddDate(0, 1, -1).Day())
+	return first.AddDate(0, 0, day-1)
+}
+
+// ParseDateExpression returns the date described by expr, relative to the
+// current day of the editor and today. Accepted are
+//   - absolute dates: 08.01., 08.01.2025, 2025-01-08
+//   - offsets in days, weeks or months: -3d, +2w, +1m, -3
+//   - today, yesterday, tomorrow
+//   - weekdays of the current week, optionally of the last or next one:
+//     mon, last fri, next tue
+//   - months, the first day of the month is used: mar, march
+//   - last or next week or month
+//
+// Words can be English or German. Dates without a year are in year.
+func ParseDateExpression(expr string, current, today time.Time, year int) (time.Time, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	if expr == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+
+	if match := absoluteDatePattern.FindStringSubmatch(expr); match != nil {
+		day, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		if match[3] != "" {
+			year, _ = strconv.Atoi(match[3])
+			if len(match[3]) == 2 {
+				year += 2000
+			}
+		}
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, current.Location())
+		if date.Day() != day || int(date.Month()) != month {
+			return time.Time{}, fmt.Errorf("invalid date %q", expr)
+		}
+		return date, nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", expr, current.Location()); err == nil {
+		return date, nil
+	}
+
+	if match := relativeDatePattern.FindStringSubmatch(expr); match != nil {
+		n, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid offset %q", expr)
+		}
+		if match[1] == "-" {
+			n = -n
+		}
+		switch match[3] {
+		case "w":
+			return current.AddDate(0, 0, 7*n), nil
+		case "m":
+			return addMonths(current, n), nil
+		default:
+			return current.AddDate(0, 0, n), nil
+		}
+	}
+
+	switch {
+	case isOneOf(expr, todayWords):
+		return today, nil
+	case isOneOf(expr, yesterdayWords):
+		return today.AddDate(0, 0, -1), nil
+	case isOneOf(expr, tomorrowWords):
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	words := strings.Fields(expr)
+	direction := 0
+	if len(words) == 2 && isOneOf(words[0], lastWords) {
+		direction = -1
+		words = words[1:]
+	} else if len(words) == 2 && isOneOf(words[0], nextWords) {
+		direction = 1
+		words = words[1:]
+	}
+	if len(words) != 1 {
+		return time.Time{}, fmt.Errorf("unknown date %q", expr)
+	}
+	word := words[0]
+
+	switch {
+	case isOneOf(word, weekWords) && direction != 0:
+		return current.AddDate(0, 0, 7*direction), nil
+	case isOneOf(word, monthWords) && direction != 0:
+		return addMonths(current, direction), nil
+	}
+	if weekday, ok := matchName(word, englishLocale.Weekdays[:], germanLocale.Weekdays[:]); ok {
+		return startOfWeek(current).AddDate(0, 0, helperMod(weekday-1, 7)+7*direction), nil
+	}
+	if month, ok := matchName(word, englishLocale.Months[:], germanLocale.Months[:]); ok {
+		return time.Date(current.Year()+direction, time.Month(month+1), 1, 0, 0, 0, 0, current.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("unknown date %q", expr)
+}
+
+// workbookYear returns the year of the loaded workbook, which the editor
+// can't leave.
+func (m Model) workbookYear() int {
+	if year := m.entryList.Year(); year != 0 {
+		return year
+	}
+	return m.datepicker.currentDay.Year()
+}
+
+// goToDate shows the given day if it is in the year of the workbook.
+func (m *Model) goToDate(date time.Time) {
+	if date.Year() != m.workbookYear() {
+		m.debugMessage = trf("%s is not in %d, the year of the workbook", formatDate(date, "02.01.2006"), m.workbookYear())
+		return
+	}
+	m.datepicker.currentDay = toSheetDate(date)
+	m.clampSelectedRow()
+	m.debugMessage = trf("Showing %s", formatDate(date, "Monday, 02.01.2006"))
+}
+
+func (m *Model) goToExpression(expr string) {
+	date, err := ParseDateExpression(expr, m.datepicker.currentDay, toSheetDate(time.Now()), m.workbookYear())
+	if err != nil {
+		m.debugMessage = tr("Invalid date:") + " " + err.Error()
+		return
+	}
+	m.goToDate(date)
+}
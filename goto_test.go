@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateExpression(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC) }
+	current := day(time.January, 8) // a wednesday
+	today := day(time.March, 31)
+
+	for expr, expected := range map[string]time.Time{
+		"08.01.":     day(time.January, 8),
+		"3.2.":       day(time.February, 3),
+		"3.2.25":     day(time.February, 3),
+		"2025-01-20": day(time.January, 20),
+		"-3d":        day(time.January, 5),
+		"+2w":        day(time.January, 22),
+		"+1m":        day(time.February, 8),
+		"-3":         day(time.January, 5),
+		"today":      today,
+		"Gestern":    day(time.March, 30),
+		"mon":        day(time.January, 6),
+		"fri":        day(time.January, 10),
+		"last fri":   day(time.January, 3),
+		"next tue":   day(time.January, 14),
+		"Mi":         day(time.January, 8),
+		"march":      day(time.March, 1),
+		"mär":        day(time.March, 1),
+		"next month": day(time.February, 8),
+		"last week":  day(time.January, 1),
+	} {
+		date, err := ParseDateExpression(expr, current, today, 2025)
+		if err != nil {
+			t.Errorf("%q: %s", expr, err)
+			continue
+		}
+		if !date.Equal(expected) {
+			t.Errorf("%q: expected %s, got %s", expr, expected.Format("02.01.2006"), date.Format("02.01.2006"))
+		}
+	}
+
+	for _, expr := range []string{"", "31.02.", "ma", "ju", "f", "next", "last year", "+3y"} {
+		if _, err := ParseDateExpression(expr, current, today, 2025); err == nil {
+			t.Errorf("expected %q to be invalid", expr)
+		}
+	}
+
+	if date := addMonths(day(time.January, 31), 1); !date.Equal(day(time.February, 28)) {
+		t.Errorf("expected the last day of february, got %s", date.Format("02.01.2006"))
+	}
+}
+
+func TestGoToDateStaysInWorkbook(t *testing.T) {
+	entryList := newBulkTestEntries()
+	entryList.Entries[2] = make([][]RowEntry, 31)
+	m := Model{datepicker: DatePicker{currentDay: time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)}, entryList: entryList}
+
+	m.goToExpression("last mon")
+	if day := m.datepicker.currentDay; day.Year() != 2025 || day.Day() != 7 {
+		t.Errorf("expected a date in the previous year to be rejected, got %s", day)
+	}
+	m.goToExpression("15.03.")
+	if day := m.datepicker.currentDay; day.Month() != time.March || day.Day() != 15 {
+		t.Errorf("expected to go to the 15th of march, got %s", day)
+	}
+	m.goToDate(addMonths(m.datepicker.currentDay, 12))
+	if day := m.datepicker.currentDay; day.Year() != 2025 {
+		t.Errorf("expected the year of the workbook to be kept, got %s", day)
+	}
+}
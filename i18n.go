@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+const (
+	LANG_EN = "en"
+	LANG_DE = "de"
+)
+
+// Locale holds the UI strings, weekday and month names of a language.
+// Messages are identified by their English text, which is also used for
+// missing translations.
+type Locale struct {
+	Lang          string
+	Messages      map[string]string
+	Weekdays      [7]string // Sunday first, like time.Weekday
+	WeekdaysShort [7]string
+	Months        [12]string
+	MonthsShort   [12]string
+}
+
+var englishLocale = Locale{
+	Lang:          LANG_EN,
+	Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	WeekdaysShort: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	Months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	MonthsShort:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+}
+
+var germanLocale = Locale{
+	Lang:          LANG_DE,
+	Messages:      germanMessages,
+	Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	WeekdaysShort: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	Months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	MonthsShort:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+}
+
+var locales = map[string]Locale{
+	LANG_EN: englishLocale,
+	LANG_DE: germanLocale,
+}
+
+// locale is the language of the UI, see SetLanguage.
+var locale = englishLocale
+
+// detectLanguage picks the UI language from the flag, the configuration or
+// the environment, in this order. Unknown languages fall back to English.
+func detectLanguage(flagLang, configLang string) string {
+	for _, lang := range []string{flagLang, configLang, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		if lang == "" {
+			continue
+		}
+		// e.g. "de_DE.UTF-8"
+		lang = strings.ToLower(lang)
+		if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+			lang = lang[:i]
+		}
+		if _, ok := locales[lang]; ok {
+			return lang
+		}
+		return LANG_EN
+	}
+	return LANG_EN
+}
+
+// SetLanguage switches the UI language and recreates the key bindings so
+// their help is translated.
+func SetLanguage(lang string) {
+	l, ok := locales[lang]
+	if !ok {
+		l = englishLocale
+	}
+	locale = l
+	keys = newKeyMap()
+}
+
+// tr translates a message into the UI language.
+func tr(message string) string {
+	if translated, ok := locale.Messages[message]; ok {
+		return translated
+	}
+	return message
+}
+
+// trf translates a format string and formats it.
+func trf(format string, args ...interface{}) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+// formatDate formats t like time.Format, using the weekday and month names
+// of the UI language.
+func formatDate(t time.Time, layout string) string {
+	s := t.Format(layout)
+	if locale.Lang == LANG_EN {
+		return s
+	}
+	// long names first, the short ones are their prefixes
+	replacer := strings.NewReplacer(
+		englishLocale.Weekdays[t.Weekday()], locale.Weekdays[t.Weekday()],
+		englishLocale.Months[t.Month()-1], locale.Months[t.Month()-1],
+		englishLocale.WeekdaysShort[t.Weekday()], locale.WeekdaysShort[t.Weekday()],
+		englishLocale.MonthsShort[t.Month()-1], locale.MonthsShort[t.Month()-1],
+	)
+	return replacer.Replace(s)
+}
+
+// viewChoices lists the keys and actions of bindings, e.g. to answer a
+// question.
+func viewChoices(bindings ...key.Binding) string {
+	choices := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		choices = append(choices, fmt.Sprintf("[%s] %s", binding.Help().Key, binding.Help().Desc))
+	}
+	return strings.Join(choices, "  ")
+}
@@ -0,0 +1,157 @@
+package main
+
+// germanMessages translates the UI strings, keyed by their English text.
+var germanMessages = map[string]string{
+	// key bindings
+	"Add":                    "Hinzufügen",
+	"Delete":                 "Löschen",
+	"Templates for day":      "Vorlagen für Tag",
+	"Templates for week":     "Vorlagen für Woche",
+	"Templates for month":    "Vorlagen für Monat",
+	"Yank entry":             "Eintrag kopieren",
+	"Yank day":               "Tag kopieren",
+	"Paste":                  "Einfügen",
+	"Copy previous workday":  "Letzten Arbeitstag kopieren",
+	"Copy last week":         "Letzte Woche kopieren",
+	"Visual selection":       "Auswahl",
+	"Bulk edit selection":    "Auswahl bearbeiten",
+	"Search":                 "Suchen",
+	"Next search result":     "Nächster Treffer",
+	"Previous search result": "Vorheriger Treffer",
+	"Up":                     "Hoch",
+	"Down":                   "Runter",
+	"Left":                   "Links",
+	"Right":                  "Rechts",
+	"Edit":                   "Bearbeiten",
+	"Cancel":                 "Abbrechen",
+	"Next input":             "Nächstes Feld",
+	"Prev input":             "Vorheriges Feld",
+	"Save":                   "Speichern",
+	"Previous Day":           "Vorheriger Tag",
+	"Next Day":               "Nächster Tag",
+	"Previous week":          "Vorherige Woche",
+	"Next week":              "Nächste Woche",
+	"Previous month":         "Vorheriger Monat",
+	"Next month":             "Nächster Monat",
+	"Today":                  "Heute",
+	"Go to date":             "Gehe zu Datum",
+	"Go to:":                 "Gehe zu:",
+	"Quit":                   "Beenden",
+	"Toggle help":            "Hilfe ein/aus",
+	"Select project number":  "Projektnummer wählen",
+	"Discard":                "Verwerfen",
+	"Keep mine":              "Meine behalten",
+	"Take theirs":            "Ihre übernehmen",
+	"Toggle duration format": "Zeitformat wechseln",
+	"Choose theme":           "Farbschema wählen",
+	"Use theme":              "Farbschema verwenden",
+
+	// editor
+	"Work Hour Editor": "Arbeitszeiteditor",
+	"read-only":        "schreibgeschützt",
+	"Current Date":     "Aktuelles Datum",
+	"Date":             "Datum",
+	"Start":            "Beginn",
+	"End":              "Ende",
+	"Pause":            "Pause",
+	"Project":          "Projekt",
+	"Description":      "Beschreibung",
+	"Project-Nr.":      "Projekt-Nr.",
+	"Note":             "Notiz",
+	"Total hours: %s":  "Stunden gesamt: %s",
+	"%d-%d of %d":      "%d-%d von %d",
+	"worked on a %s":   "Arbeit am %s",
+	"weekend":          "Wochenende",
+	"holiday":          "Feiertag",
+	"Month: %s, thereof %s on weekends and holidays": "Monat: %s, davon %s an Wochenenden und Feiertagen",
+	"-- VISUAL -- %d entries selected":               "-- AUSWAHL -- %d Einträge ausgewählt",
+	"regular expression":                             "regulärer Ausdruck",
+	"Yanked %d entries":                              "%d Einträge kopiert",
+	"(system clipboard failed)":                      "(Systemzwischenablage fehlgeschlagen)",
+	"No templates configured!":                       "Keine Vorlagen konfiguriert!",
+	"Added %d entries from templates (%s - %s)":      "%d Einträge aus Vorlagen hinzugefügt (%s - %s)",
+	"Reached first day of the year!":                 "Erster Tag des Jahres erreicht!",
+	"Reached last day of the year!":                  "Letzter Tag des Jahres erreicht!",
+	"No entry to yank!":                              "Kein Eintrag zum Kopieren!",
+	"No entries to yank!":                            "Keine Einträge zum Kopieren!",
+	"Nothing yanked yet!":                            "Noch nichts kopiert!",
+	"Pasted %d entries":                              "%d Einträge eingefügt",
+	"Copied %d entries from %s":                      "%d Einträge von %s kopiert",
+	"Copied %d entries from last week":               "%d Einträge aus der letzten Woche kopiert",
+	"Deleted %d entries":                             "%d Einträge gelöscht",
+	"Saved entry starting at":                        "Eintrag gespeichert, Beginn",
+	"Bulk edit failed:":                              "Bearbeiten fehlgeschlagen:",
+	"Applied %q to %d entries":                       "%q auf %d Einträge angewendet",
+	"Stored as %s - %s (%s)":                         "Gespeichert als %s - %s (%s)",
+	"Showing durations as %s":                        "Zeiten in %s",
+	"hours and minutes":                              "Stunden und Minuten",
+	"decimal hours":                                  "Dezimalstunden",
+	"industrial minutes":                             "Industrieminuten",
+	"Theme %d/%d: %s":                                "Farbschema %d/%d: %s",
+	"Using theme %s, set \"Theme\": %q in the configuration to keep it": "Farbschema %s aktiv, \"Theme\": %q in der Konfiguration setzen, um es beizubehalten",
+
+	// working hours act
+	"Worked %s, more than the maximum of %s per day":    "%s gearbeitet, mehr als die erlaubten %s pro Tag",
+	"Breaks of %s are shorter than the required %s":     "Pausen von %s sind kürzer als die vorgeschriebenen %s",
+	"Rest period of %s is shorter than the required %s": "Ruhezeit von %s ist kürzer als die vorgeschriebenen %s",
+	"Break %s is shorter than %s and does not count":    "Pause %s ist kürzer als %s und zählt nicht",
+
+	// search
+	"Invalid search:":                 "Ungültige Suche:",
+	"Found %d entries matching /%s/":  "%d Einträge passend zu /%s/ gefunden",
+	"No search results!":              "Keine Suchergebnisse!",
+	"Search result %d/%d for /%s/":    "Suchergebnis %d/%d für /%s/",
+	"Search results for /%s/ (%d/%d)": "Suchergebnisse für /%s/ (%d/%d)",
+
+	// go to date
+	"Invalid date:": "Ungültiges Datum:",
+	"Showing %s":    "Zeige %s",
+	"%s is not in %d, the year of the workbook": "%s liegt nicht in %d, dem Jahr der Arbeitsmappe",
+
+	// saving and quitting
+	"Workbook was opened read-only, cannot save!":                        "Arbeitsmappe ist schreibgeschützt geöffnet, Speichern nicht möglich!",
+	"Workbook was changed on disk, resolve the conflicts before saving!": "Arbeitsmappe wurde auf der Festplatte geändert, vor dem Speichern die Konflikte lösen!",
+	"Could not preview changes:":                                         "Änderungen konnten nicht angezeigt werden:",
+	"Saved to":                                                           "Gespeichert in",
+	"Save aborted":                                                       "Speichern abgebrochen",
+	"Could not save:":                                                    "Konnte nicht gespeichert werden:",
+	"Changes to":                                                         "Änderungen an",
+	"Unsaved changes on %d days!":                                        "Ungespeicherte Änderungen an %d Tagen!",
+	"No changes.":                                                        "Keine Änderungen.",
+	"%s: + row %d inserted: %s":                                          "%s: + Zeile %d eingefügt: %s",
+	"%s: - row %d removed: %s":                                           "%s: - Zeile %d entfernt: %s",
+	"%s: ~ row %d modified (now row %d):":                                "%s: ~ Zeile %d geändert (jetzt Zeile %d):",
+
+	// external changes
+	"Workbook was changed on disk! Merged the changes, %d conflicts.": "Arbeitsmappe wurde auf der Festplatte geändert! Änderungen zusammengeführt, %d Konflikte.",
+	"Resolved all conflicts": "Alle Konflikte gelöst",
+	"Conflict %d/%d on %s: entry changed here and in the workbook on disk": "Konflikt %d/%d am %s: Eintrag hier und in der Arbeitsmappe geändert",
+	"(none)":    "(keiner)",
+	"Original:": "Original:",
+	"Mine:":     "Meiner:",
+	"Theirs:":   "Ihrer:",
+
+	// startup
+	"%s is already opened by %s":               "%s ist bereits geöffnet von %s",
+	"since":                                    "seit",
+	"Open it read-only?":                       "Schreibgeschützt öffnen?",
+	"Locked meanwhile, opening it read-only":   "Inzwischen gesperrt, wird schreibgeschützt geöffnet",
+	"Found unsaved changes of a previous run:": "Ungespeicherte Änderungen einer früheren Sitzung gefunden:",
+	"%s (changed %s):":                         "%s (geändert %s):",
+	"(all entries deleted)":                    "(alle Einträge gelöscht)",
+	"Restore these changes?":                   "Diese Änderungen wiederherstellen?",
+	"[y/N]":                                    "[j/N]",
+	"Failed to create billing statement:":      "Abrechnung konnte nicht erstellt werden:",
+	"Loading sheet...":                         "Lade Tabelle...",
+	"Failed to load data!":                     "Laden der Daten fehlgeschlagen!",
+	"Loading...":                               "Lade...",
+
+	// billing
+	"Statement":                           "Abrechnung",
+	"Statement for":                       "Abrechnung für",
+	"Period":                              "Zeitraum",
+	"Hours":                               "Stunden",
+	"Subtotal":                            "Zwischensumme",
+	"Total":                               "Gesamt",
+	"Surcharge for weekends and holidays": "Zuschlag für Wochenenden und Feiertage",
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestGermanCatalogue checks that every message passed to tr and trf as a
+// literal, including the ones of the billing template, is translated.
+func TestGermanCatalogue(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := make(map[string]bool)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(parsed, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if ident, ok := call.Fun.(*ast.Ident); !ok || (ident.Name != "tr" && ident.Name != "trf") {
+				return true
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				message, _ := strconv.Unquote(lit.Value)
+				messages[message] = true
+			}
+			return true
+		})
+	}
+	for _, match := range regexp.MustCompile(`\{\{tr "([^"]*)"\}\}`).FindAllStringSubmatch(billingTemplate.Tree.Root.String(), -1) {
+		messages[match[1]] = true
+	}
+	messages["weekend"], messages["holiday"] = true, true
+	for _, label := range durationModeLabels {
+		messages[label] = true
+	}
+
+	if len(messages) < 50 {
+		t.Fatalf("expected to find the UI messages, got %d", len(messages))
+	}
+	for message := range messages {
+		if _, ok := germanMessages[message]; !ok {
+			t.Errorf("missing German translation of %q", message)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	defer SetLanguage(LANG_EN)
+	date := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	if s := formatDate(date, "Mon 02.01. January"); s != "Mon 03.03. March" {
+		t.Errorf("unexpected English date %q", s)
+	}
+	SetLanguage(LANG_DE)
+	if s := formatDate(date, "Mon 02.01. January"); s != "Mo 03.03. März" {
+		t.Errorf("unexpected German date %q", s)
+	}
+	if keys.Quit.Help().Desc != "Beenden" {
+		t.Errorf("expected key help to be translated, got %q", keys.Quit.Help().Desc)
+	}
+	// the workbook keeps its own weekday names
+	if entry := retargetEntries([]RowEntry{{}}, date, "03")[0]; entry.Day != "Mo" {
+		t.Errorf("unexpected workbook weekday %q", entry.Day)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	if lang := detectLanguage("", ""); lang != LANG_DE {
+		t.Errorf("expected language from LANG, got %q", lang)
+	}
+	if lang := detectLanguage("", "en"); lang != LANG_EN {
+		t.Errorf("expected configured language, got %q", lang)
+	}
+	if lang := detectLanguage("de", "en"); lang != LANG_DE {
+		t.Errorf("expected language of the flag, got %q", lang)
+	}
+	t.Setenv("LANG", "fr_FR.UTF-8")
+	if lang := detectLanguage("", ""); lang != LANG_EN {
+		t.Errorf("expected English for unknown languages, got %q", lang)
+	}
+}
+
+func TestGermanEditInputs(t *testing.T) {
+	defer SetLanguage(LANG_EN)
+	SetLanguage(LANG_DE)
+
+	date := time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)
+	entry := RowEntry{Date: date, Start: date.Add(8 * time.Hour), End: date.Add(9 * time.Hour)}
+	m := Model{datepicker: DatePicker{currentDay: date}, numColumns: 6}
+	m.textInputs = m.newEditInputs(entry)
+	if m.textInputs[3].Placeholder != "Beschreibung" || m.textInputs[4].Placeholder != "Projekt-Nr." {
+		t.Errorf("expected German placeholders, got %q and %q", m.textInputs[3].Placeholder, m.textInputs[4].Placeholder)
+	}
+	if saved := m.entryFromInputs(entry); saved.Description != "" || saved.ProjectNr != "" {
+		t.Errorf("expected the placeholders not to be saved, got %q and %q", saved.Description, saved.ProjectNr)
+	}
+}
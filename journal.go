@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// JournalRecord holds all entries of a day after it was changed.
+type JournalRecord struct {
+	Time    time.Time
+	Date    time.Time
+	Entries []RowEntry
+}
+
+// Journal is an append-only log of all changes which have not been saved to
+// the workbook yet. It allows restoring them after a crash.
+type Journal struct {
+	path string
+	file *os.File
+}
+
+// journalPath returns the path of the journal belonging to a workbook, which
+// is a hidden file next to it.
+func journalPath(excelFileName string) string {
+	dir, name := filepath.Split(excelFileName)
+	return filepath.Join(dir, "."+name+".journal")
+}
+
+func OpenJournal(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{path: path, file: file}, nil
+}
+
+// Record appends the entries of date to the journal.
+func (j *Journal) Record(date time.Time, entries []RowEntry) error {
+	if j == nil {
+		return nil
+	}
+	data, err := json.Marshal(JournalRecord{Time: time.Now(), Date: toSheetDate(date), Entries: entries})
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return j.file.Sync()
+}
+
+// Clear removes all records, e.g. after the changes were saved.
+func (j *Journal) Clear() error {
+	if j == nil {
+		return nil
+	}
+	return j.file.Truncate(0)
+}
+
+// Remove closes and deletes the journal.
+func (j *Journal) Remove() error {
+	if j == nil {
+		return nil
+	}
+	j.file.Close()
+	return os.Remove(j.path)
+}
+
+// ReadJournal returns all records of the journal at path.
+func ReadJournal(path string) ([]JournalRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []JournalRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record JournalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// the last line may be incomplete if we crashed while writing it
+			slog.Warn("Skipping invalid journal record", "file", path, "error", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// PendingJournal returns the latest record of every day in the journal of the
+// workbook if the journal is newer than the workbook.
+func PendingJournal(excelFileName string) ([]JournalRecord, error) {
+	journalInfo, err := os.Stat(journalPath(excelFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	workbookInfo, err := os.Stat(excelFileName)
+	if err != nil {
+		return nil, err
+	}
+	if journalInfo.Size() == 0 || !journalInfo.ModTime().After(workbookInfo.ModTime()) {
+		return nil, nil
+	}
+
+	records, err := ReadJournal(journalPath(excelFileName))
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[time.Time]JournalRecord)
+	for _, record := range records {
+		latest[record.Date] = record
+	}
+	res := make([]JournalRecord, 0, len(latest))
+	for _, record := range latest {
+		res = append(res, record)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Date.Before(res[j].Date) })
+	return res, nil
+}
+
+// ReplayJournal replaces the entries of every recorded day with the recorded
+// ones and returns the restored days.
+func (e *EntryList) ReplayJournal(records []JournalRecord) []time.Time {
+	var restored []time.Time
+	for _, record := range records {
+		day := e.dayEntries(record.Date)
+		if day == nil {
+			slog.Warn("Cannot restore entries of unloaded month", "date", record.Date)
+			continue
+		}
+		*day = record.Entries
+		restored = append(restored, record.Date)
+	}
+	return restored
+}
+
+// DescribeJournal lists the entries which would be restored from records.
+func DescribeJournal(records []JournalRecord) string {
+	s := ""
+	for _, record := range records {
+		s += trf("%s (changed %s):", formatDate(record.Date, "Mon 02.01.2006"), record.Time.Format("02.01. 15:04")) + "\n"
+		if len(record.Entries) == 0 {
+			s += "  " + tr("(all entries deleted)") + "\n"
+		}
+		for _, entry := range record.Entries {
+			s += "  " + entry.View() + "\n"
+		}
+	}
+	return s
+}
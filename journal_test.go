@@ -0,0 +1,151 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestJournalReplay(t *testing.T) {
+	workbook := filepath.Join(t.TempDir(), "hours.xlsx")
+	if err := os.WriteFile(workbook, []byte{}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(workbook, old, old)
+
+	journal, err := OpenJournal(journalPath(workbook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	first := []RowEntry{{Date: date, Start: date.Add(8 * time.Hour), End: date.Add(9 * time.Hour), Description: "First"}}
+	second := append(first, RowEntry{Date: date, Start: date.Add(9 * time.Hour), End: date.Add(10 * time.Hour), Description: "Second"})
+	journal.Record(date, first)
+	journal.Record(date, second)
+
+	pending, err := PendingJournal(workbook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || len(pending[0].Entries) != 2 {
+		t.Fatalf("expected the latest record of one day, got %+v", pending)
+	}
+
+	entries := make([][][]RowEntry, 12)
+	entries[0] = make([][]RowEntry, 31)
+	entryList := EntryList{Entries: entries}
+	if restored := entryList.ReplayJournal(pending); len(restored) != 1 || !restored[0].Equal(date) {
+		t.Errorf("unexpected restored days: %v", restored)
+	}
+	if day := entryList.Entries[0][5]; len(day) != 2 || day[1].Description != "Second" || !day[1].End.Equal(second[1].End) {
+		t.Errorf("entries were not restored: %+v", day)
+	}
+
+	journal.Clear()
+	if pending, _ := PendingJournal(workbook); len(pending) != 0 {
+		t.Errorf("expected no pending changes after clearing the journal, got %+v", pending)
+	}
+	journal.Remove()
+}
+
+func TestJournalRecordsEditedEntry(t *testing.T) {
+	workbook := filepath.Join(t.TempDir(), "hours.xlsx")
+	if err := os.WriteFile(workbook, []byte{}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(workbook, old, old)
+	journal, err := OpenJournal(journalPath(workbook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Remove()
+
+	var m tea.Model = Model{
+		datepicker: DatePicker{currentDay: time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)},
+		entryList:  newBulkTestEntries(),
+		modified:   make(map[time.Time]bool),
+		journal:    journal,
+		numColumns: 6,
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	edited := m.(Model)
+	edited.textInputs[3].SetValue("Edited")
+	m, _ = edited.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	pending, err := PendingJournal(workbook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Entries[0].Description != "Edited" {
+		t.Fatalf("expected the journal to hold the edited entry, got %+v", pending)
+	}
+	entries := newBulkTestEntries()
+	entries.ReplayJournal(pending)
+	if description := entries.Entries[0][6][0].Description; description != "Edited" {
+		t.Errorf("expected replaying the journal to restore the edit, got %q", description)
+	}
+}
+
+func TestFailedSaveKeepsChanges(t *testing.T) {
+	dir := t.TempDir()
+	workbook := filepath.Join(dir, "hours.xlsx")
+	if err := excelize.NewFile().SaveAs(workbook); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(workbook, old, old)
+	f, err := excelize.OpenFile(workbook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	state, err := readFileState(workbook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal, err := OpenJournal(journalPath(workbook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Remove()
+
+	date := time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)
+	m := Model{
+		datepicker: DatePicker{currentDay: date},
+		entryList:  EntryList{Entries: make([][][]RowEntry, 12)},
+		modified:   map[time.Time]bool{date: true},
+		journal:    journal,
+		fileState:  state,
+		config: Configuration{
+			ExcelFile:     f,
+			ExcelFileName: workbook,
+			// the directory doesn't exist, so writing fails
+			OutputFile: filepath.Join(dir, "missing", "hours.xlsx"),
+			SaveOnQuit: true,
+		},
+	}
+	journal.Record(date, nil)
+
+	if m.save() {
+		t.Fatal("expected saving to a missing directory to fail")
+	}
+	if !m.isModified(date) {
+		t.Error("expected the day to stay modified")
+	}
+	if pending, _ := PendingJournal(workbook); len(pending) != 1 {
+		t.Errorf("expected the journal to be kept, got %+v", pending)
+	}
+	if cmd := m.quit(); cmd != nil || m.quitConfirmActive {
+		t.Error("expected to stay in the editor if saving on quit fails")
+	}
+	if !strings.HasPrefix(m.debugMessage, "Could not save:") {
+		t.Errorf("expected the error to be shown, got %q", m.debugMessage)
+	}
+}
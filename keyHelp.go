@@ -16,6 +16,13 @@ type keyMap struct {
 	Left    key.Binding
 	Right   key.Binding
 
+	PrevWeek  key.Binding
+	NextWeek  key.Binding
+	PrevMonth key.Binding
+	NextMonth key.Binding
+	Today     key.Binding
+	GoToDate  key.Binding
+
 	Edit       key.Binding
 	CancelEdit key.Binding
 	Save       key.Binding
@@ -25,8 +32,35 @@ type keyMap struct {
 	Add    key.Binding
 	Delete key.Binding
 
+	TemplatesDay   key.Binding
+	TemplatesWeek  key.Binding
+	TemplatesMonth key.Binding
+
+	YankEntry       key.Binding
+	YankDay         key.Binding
+	Paste           key.Binding
+	CopyPrevWorkday key.Binding
+	CopyLastWeek    key.Binding
+
+	Visual      key.Binding
+	BulkCommand key.Binding
+
+	Search     key.Binding
+	NextResult key.Binding
+	PrevResult key.Binding
+
+	DurationMode key.Binding
+	ThemePicker  key.Binding
+
 	ArrowUp   key.Binding
 	ArrowDown key.Binding
+
+	ConfirmSave    key.Binding
+	ConfirmDiscard key.Binding
+	ConfirmCancel  key.Binding
+
+	ConflictOurs   key.Binding
+	ConflictTheirs key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
@@ -39,80 +73,200 @@ func (k keyMap) ShortHelp() []key.Binding {
 // key.Map interface.
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.PrevDay, k.Left, k.Down, k.FocusPrev, k.Edit, k.Add, k.CancelEdit, k.Help}, // first column
-		{k.NextDay, k.Right, k.Up, k.FocusNext, k.Save, k.Delete, k.Quit},             // second column
+		{k.PrevDay, k.Left, k.Down, k.FocusPrev, k.Edit, k.Add, k.CancelEdit, k.Help},                  // first column
+		{k.NextDay, k.Right, k.Up, k.FocusNext, k.Save, k.Delete, k.Quit},                              // second column
+		{k.PrevWeek, k.NextWeek, k.PrevMonth, k.NextMonth, k.Today, k.GoToDate},                        // third column
+		{k.TemplatesDay, k.TemplatesWeek, k.TemplatesMonth},                                            // fourth column
+		{k.YankEntry, k.YankDay, k.Paste, k.CopyPrevWorkday, k.CopyLastWeek},                           // fifth column
+		{k.Visual, k.BulkCommand, k.Search, k.NextResult, k.PrevResult, k.DurationMode, k.ThemePicker}, // sixth column
 	}
 }
 
-var keys = keyMap{
-	Add: key.NewBinding(
-		key.WithKeys("a"),
-		key.WithHelp("a", "Add"),
-	),
-	Delete: key.NewBinding(
-		key.WithKeys("d"),
-		key.WithHelp("d", "Delete"),
-	),
-	Up: key.NewBinding(
-		key.WithKeys("k"),
-		key.WithHelp("k", "Up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("j"),
-		key.WithHelp("j", "Down"),
-	),
-	Left: key.NewBinding(
-		key.WithKeys("h"),
-		key.WithHelp("h", "Left"),
-	),
-	Right: key.NewBinding(
-		key.WithKeys("l"),
-		key.WithHelp("l", "Right"),
-	),
-	Edit: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "Edit"),
-	),
-	CancelEdit: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "Cancel"),
-	),
-
-	FocusNext: key.NewBinding(
-		key.WithKeys("tab"),
-		key.WithHelp("tab", "Next input"),
-	),
-	FocusPrev: key.NewBinding(
-		key.WithKeys("shift+tab"),
-		key.WithHelp("shift+tab", "Prev input"),
-	),
-	Save: key.NewBinding(
-		key.WithKeys("ctrl+s"),
-		key.WithHelp("ctrl+s", "Save"),
-	),
-	PrevDay: key.NewBinding(
-		key.WithKeys("q", "ctrl+h"),
-		key.WithHelp("q", "Previous Day"),
-	),
-	NextDay: key.NewBinding(
-		key.WithKeys("e", "ctrl+l"),
-		key.WithHelp("e", "Next Day"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("ctrl+c"),
-		key.WithHelp("crtl+c", "quit"),
-	),
-	Help: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "toggle help"),
-	),
-
-	ArrowUp: key.NewBinding(
-		key.WithKeys("up"),
-		key.WithHelp("↑", "select project-nr."),
-	),
-	ArrowDown: key.NewBinding(
-		key.WithKeys("down"),
-		key.WithHelp("↓", "select project-nr."),
-	),
+// keys are the key bindings of the editor. They are recreated by
+// SetLanguage to translate their help.
+var keys = newKeyMap()
+
+// newKeyMap creates the default key bindings with the overrides of the preset
+// and the configuration applied, see SetKeyBindings.
+func newKeyMap() keyMap {
+	k := keyMap{
+		Add: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", tr("Add")),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", tr("Delete")),
+		),
+		TemplatesDay: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", tr("Templates for day")),
+		),
+		TemplatesWeek: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", tr("Templates for week")),
+		),
+		TemplatesMonth: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", tr("Templates for month")),
+		),
+		YankEntry: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", tr("Yank entry")),
+		),
+		YankDay: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", tr("Yank day")),
+		),
+		Paste: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", tr("Paste")),
+		),
+		CopyPrevWorkday: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", tr("Copy previous workday")),
+		),
+		CopyLastWeek: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", tr("Copy last week")),
+		),
+		Visual: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", tr("Visual selection")),
+		),
+		BulkCommand: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", tr("Bulk edit selection")),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", tr("Search")),
+		),
+		NextResult: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", tr("Next search result")),
+		),
+		PrevResult: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", tr("Previous search result")),
+		),
+		DurationMode: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", tr("Toggle duration format")),
+		),
+		ThemePicker: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", tr("Choose theme")),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("k"),
+			key.WithHelp("k", tr("Up")),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j"),
+			key.WithHelp("j", tr("Down")),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", tr("Left")),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", tr("Right")),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", tr("Edit")),
+		),
+		CancelEdit: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", tr("Cancel")),
+		),
+
+		FocusNext: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", tr("Next input")),
+		),
+		FocusPrev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", tr("Prev input")),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", tr("Save")),
+		),
+		PrevDay: key.NewBinding(
+			key.WithKeys("q", "ctrl+h"),
+			key.WithHelp("q", tr("Previous Day")),
+		),
+		NextDay: key.NewBinding(
+			key.WithKeys("e", "ctrl+l"),
+			key.WithHelp("e", tr("Next Day")),
+		),
+		PrevWeek: key.NewBinding(
+			key.WithKeys("Q"),
+			key.WithHelp("Q", tr("Previous week")),
+		),
+		NextWeek: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", tr("Next week")),
+		),
+		PrevMonth: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", tr("Previous month")),
+		),
+		NextMonth: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", tr("Next month")),
+		),
+		Today: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", tr("Today")),
+		),
+		GoToDate: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", tr("Go to date")),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", tr("Quit")),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", tr("Toggle help")),
+		),
+
+		ArrowUp: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", tr("Select project number")),
+		),
+		ArrowDown: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", tr("Select project number")),
+		),
+
+		ConfirmSave: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", tr("Save")),
+		),
+		ConfirmDiscard: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", tr("Discard")),
+		),
+		ConfirmCancel: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", tr("Cancel")),
+		),
+
+		ConflictOurs: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", tr("Keep mine")),
+		),
+		ConflictTheirs: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", tr("Take theirs")),
+		),
+	}
+	k.override(keyOverrides)
+	return k
 }
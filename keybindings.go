@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Presets of key bindings, see SetKeyBindings.
+const (
+	KEYS_DEFAULT = "default"
+	KEYS_VIM     = "vim"
+	KEYS_ARROWS  = "arrows" // arrow, page and function keys
+)
+
+// keyPresets override the default bindings, keyed by action like the
+// Keys of the configuration.
+var keyPresets = map[string]map[string][]string{
+	KEYS_DEFAULT: {},
+	KEYS_VIM: {
+		"PrevDay": {"b", "ctrl+h"},
+		"NextDay": {"w", "ctrl+l"},
+		"Delete":  {"d", "x"},
+	},
+	KEYS_ARROWS: {
+		"Up":         {"up"},
+		"Down":       {"down"},
+		"Left":       {"left"},
+		"Right":      {"right"},
+		"PrevDay":    {"pgup", "ctrl+left"},
+		"NextDay":    {"pgdown", "ctrl+right"},
+		"PrevWeek":   {"ctrl+up"},
+		"NextWeek":   {"ctrl+down"},
+		"PrevMonth":  {"ctrl+pgup"},
+		"NextMonth":  {"ctrl+pgdown"},
+		"Today":      {"home"},
+		"GoToDate":   {"ctrl+g", "f5"},
+		"Add":        {"insert", "f2"},
+		"Delete":     {"delete", "f8"},
+		"Edit":       {"enter", "f4"},
+		"Search":     {"ctrl+f", "/"},
+		"NextResult": {"f3"},
+		"PrevResult": {"shift+f3"},
+		"Help":       {"f1", "?"},
+		"Quit":       {"ctrl+c", "ctrl+q"},
+	},
+}
+
+// keyModes lists the actions handled at the same time, e.g. while editing
+// an entry. A key must not be bound to two actions of a mode.
+var keyModes = map[string][]string{
+	"normal": {"PrevDay", "NextDay", "PrevWeek", "NextWeek", "PrevMonth", "NextMonth", "Today", "GoToDate", "Up", "Down", "Left", "Right", "Save", "TemplatesDay", "TemplatesWeek", "TemplatesMonth",
+		"YankEntry", "YankDay", "Paste", "CopyPrevWorkday", "CopyLastWeek", "FocusPrev", "FocusNext", "Search", "NextResult", "PrevResult",
+		"Visual", "BulkCommand", "Delete", "Add", "Edit", "CancelEdit", "DurationMode", "ThemePicker", "Help", "Quit"},
+	"edit":     {"Edit", "CancelEdit", "FocusPrev", "FocusNext", "ArrowUp", "ArrowDown", "Help", "Quit"},
+	"list":     {"Up", "Down", "Edit", "CancelEdit", "Quit"},
+	"confirm":  {"ConfirmSave", "ConfirmDiscard", "ConfirmCancel", "Quit"},
+	"conflict": {"ConflictOurs", "ConflictTheirs", "Quit"},
+}
+
+// typedWhileEditing are the actions which ignore keys typing text while an
+// entry is edited, so they may be bound to single characters, see isTyped.
+var typedWhileEditing = map[string]bool{"Help": true, "Quit": true}
+
+// isTyped reports whether msg types text, e.g. into the inputs of the edited
+// entry.
+func isTyped(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace
+}
+
+// keyOverrides are the bindings of the preset and the configuration which
+// newKeyMap applies to the defaults.
+var keyOverrides map[string][]string
+
+// bindings returns the bindings of the key map by action.
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"PrevDay": &k.PrevDay, "NextDay": &k.NextDay, "Quit": &k.Quit, "Help": &k.Help,
+		"PrevWeek": &k.PrevWeek, "NextWeek": &k.NextWeek, "PrevMonth": &k.PrevMonth, "NextMonth": &k.NextMonth,
+		"Today": &k.Today, "GoToDate": &k.GoToDate,
+		"Up": &k.Up, "Down": &k.Down, "Left": &k.Left, "Right": &k.Right,
+		"Edit": &k.Edit, "CancelEdit": &k.CancelEdit, "Save": &k.Save, "FocusPrev": &k.FocusPrev, "FocusNext": &k.FocusNext,
+		"Add": &k.Add, "Delete": &k.Delete,
+		"TemplatesDay": &k.TemplatesDay, "TemplatesWeek": &k.TemplatesWeek, "TemplatesMonth": &k.TemplatesMonth,
+		"YankEntry": &k.YankEntry, "YankDay": &k.YankDay, "Paste": &k.Paste, "CopyPrevWorkday": &k.CopyPrevWorkday, "CopyLastWeek": &k.CopyLastWeek,
+		"Visual": &k.Visual, "BulkCommand": &k.BulkCommand,
+		"Search": &k.Search, "NextResult": &k.NextResult, "PrevResult": &k.PrevResult,
+		"DurationMode": &k.DurationMode, "ThemePicker": &k.ThemePicker,
+		"ArrowUp": &k.ArrowUp, "ArrowDown": &k.ArrowDown,
+		"ConfirmSave": &k.ConfirmSave, "ConfirmDiscard": &k.ConfirmDiscard, "ConfirmCancel": &k.ConfirmCancel,
+		"ConflictOurs": &k.ConflictOurs, "ConflictTheirs": &k.ConflictTheirs,
+	}
+}
+
+// override rebinds the actions to the given keys, regenerating their help.
+// An empty list of keys disables an action.
+func (k *keyMap) override(overrides map[string][]string) {
+	bindings := k.bindings()
+	for action, keys := range overrides {
+		binding, ok := bindings[action]
+		if !ok {
+			continue
+		}
+		if len(keys) == 0 {
+			binding.SetEnabled(false)
+			continue
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	}
+}
+
+// validate reports unknown actions of overrides and keys bound to several
+// actions of a mode.
+func (k *keyMap) validate() error {
+	bindings := k.bindings()
+	for action := range keyOverrides {
+		if _, ok := bindings[action]; !ok {
+			return fmt.Errorf("unknown action %q in key bindings", action)
+		}
+	}
+
+	modes := make([]string, 0, len(keyModes))
+	for mode := range keyModes {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	for _, mode := range modes {
+		boundTo := make(map[string]string)
+		for _, action := range keyModes[mode] {
+			binding := bindings[action]
+			if !binding.Enabled() {
+				continue
+			}
+			for _, k := range binding.Keys() {
+				if other, ok := boundTo[k]; ok {
+					return fmt.Errorf("key %q is bound to both %s and %s in %s mode", k, other, action, mode)
+				}
+				boundTo[k] = action
+				// typed into the inputs otherwise
+				if mode == "edit" && utf8.RuneCountInString(k) == 1 && !typedWhileEditing[action] {
+					return fmt.Errorf("key %q of %s can't be used while editing, it is typed into the inputs", k, action)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SetKeyBindings applies a preset and the bindings of the configuration on
+// top of it to the key map.
+func SetKeyBindings(preset string, overrides map[string][]string) error {
+	if preset == "" {
+		preset = KEYS_DEFAULT
+	}
+	presetKeys, ok := keyPresets[preset]
+	if !ok {
+		return fmt.Errorf("unknown key preset %q, use %s, %s or %s", preset, KEYS_DEFAULT, KEYS_VIM, KEYS_ARROWS)
+	}
+
+	merged := make(map[string][]string, len(presetKeys)+len(overrides))
+	for action, keys := range presetKeys {
+		merged[action] = keys
+	}
+	for action, keys := range overrides {
+		merged[action] = keys
+	}
+
+	previous := keyOverrides
+	keyOverrides = merged
+	keyMap := newKeyMap()
+	if err := keyMap.validate(); err != nil {
+		keyOverrides = previous
+		return err
+	}
+	keys = keyMap
+	return nil
+}
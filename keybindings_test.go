@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyBindings(t *testing.T) {
+	defer SetKeyBindings(KEYS_DEFAULT, nil)
+
+	for preset := range keyPresets {
+		if err := SetKeyBindings(preset, nil); err != nil {
+			t.Errorf("preset %s: %s", preset, err)
+		}
+	}
+
+	if err := SetKeyBindings(KEYS_ARROWS, map[string][]string{"Quit": {"ctrl+q", "ctrl+c"}, "PrevDay": {"ctrl+b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyPgDown}, keys.NextDay) {
+		t.Errorf("expected the preset to bind page down to the next day")
+	}
+	if help := keys.Quit.Help(); help.Key != "ctrl+q/ctrl+c" || help.Desc != "Quit" {
+		t.Errorf("unexpected help %+v", help)
+	}
+	if key.Matches(tea.KeyMsg{Type: tea.KeyPgUp}, keys.PrevDay) {
+		t.Errorf("expected the configuration to override the preset")
+	}
+
+	// the language switch keeps the bindings
+	SetLanguage(LANG_DE)
+	defer SetLanguage(LANG_EN)
+	if help := keys.Quit.Help(); help.Key != "ctrl+q/ctrl+c" || help.Desc != "Beenden" {
+		t.Errorf("unexpected help after switching the language %+v", help)
+	}
+
+	for _, overrides := range []map[string][]string{
+		{"Search": {"a"}},      // Add in normal mode
+		{"ConfirmSave": {"d"}}, // ConfirmDiscard
+		{"FocusNext": {"j"}},   // typed while editing
+		{"Quit": {"q"}},        // PrevDay in normal mode
+		{"Help": {"tab"}},      // FocusNext while editing
+		{"Jump": {"ctrl+j"}},   // unknown action
+	} {
+		if err := SetKeyBindings(KEYS_DEFAULT, overrides); err == nil {
+			t.Errorf("expected %v to be rejected", overrides)
+		}
+	}
+	if err := SetKeyBindings("emacs", nil); err == nil {
+		t.Errorf("expected unknown presets to be rejected")
+	}
+	// quitting ignores typed keys while editing
+	if err := SetKeyBindings(KEYS_DEFAULT, map[string][]string{"Quit": {"q", "ctrl+c"}, "PrevDay": {"ctrl+h"}}); err != nil {
+		t.Errorf("expected q to be accepted for Quit, got %s", err)
+	}
+	q := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+	var m tea.Model = Model{datepicker: DatePicker{currentDay: time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)}, entryList: newBulkTestEntries(), modified: make(map[time.Time]bool), numColumns: 6}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for i := 0; i < 3; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	m.View() // focuses the input
+	m, cmd := m.Update(q)
+	if cmd != nil && cmd() == tea.Quit() {
+		t.Errorf("expected q to be typed while editing")
+	}
+	if value := m.(Model).textInputs[3].Value(); value != "Old projectq" {
+		t.Errorf("expected q to be typed into the description, got %q", value)
+	}
+	// so does the help, bound to ? by default
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if edited := m.(Model); edited.help.ShowAll || edited.textInputs[3].Value() != "Old projectq?" {
+		t.Errorf("expected ? to be typed into the description, got %q", edited.textInputs[3].Value())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, cmd := m.Update(q); cmd == nil || cmd() != tea.Quit() {
+		t.Errorf("expected q to quit outside of editing")
+	}
+
+	// bindings of different modes may share keys
+	if err := SetKeyBindings(KEYS_DEFAULT, map[string][]string{"ConflictOurs": {"a"}, "Visual": {}}); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableLayout holds the widths of the columns of the entry table. Columns
+// of width 0 are hidden.
+type tableLayout struct {
+	Date        int
+	Pause       int
+	Project     int
+	Description int
+}
+
+// Widths of the columns which are always shown, including their separators,
+// and of the optional ones.
+const (
+	TABLE_INDENT       = 2
+	TABLE_TIMES_WIDTH  = 8 + 3 + 8 + 1 // start → end
+	TABLE_DATE_WIDTH   = 10
+	TABLE_PAUSE_WIDTH  = 9
+	TABLE_MIN_TEXT     = 10 // minimal width of the project and description
+	TABLE_MAX_PROJECT  = 30
+	TABLE_PROJECT_SEPS = 4 // " :  " after the project
+)
+
+// defaultTableLayout is used where the size of the terminal doesn't matter,
+// e.g. when listing entries on the command line.
+var defaultTableLayout = tableLayout{Date: TABLE_DATE_WIDTH, Pause: TABLE_PAUSE_WIDTH, Project: 20, Description: 20}
+
+// newTableLayout fits the entry table into width columns. The date, which is
+// shown above the table as well, is hidden first, then the pause and the
+// project. The description takes the remaining space.
+func newTableLayout(width int) tableLayout {
+	var res tableLayout
+	free := width - TABLE_INDENT - TABLE_TIMES_WIDTH
+	if free >= 80 {
+		res.Date = TABLE_DATE_WIDTH
+		free -= TABLE_DATE_WIDTH + 2
+	}
+	if free >= 50 {
+		res.Pause = TABLE_PAUSE_WIDTH
+		free -= TABLE_PAUSE_WIDTH + 3
+	}
+	if free >= 2*TABLE_MIN_TEXT+TABLE_PROJECT_SEPS {
+		res.Project = min(max(free/3, TABLE_MIN_TEXT), TABLE_MAX_PROJECT)
+		free -= res.Project + TABLE_PROJECT_SEPS
+	}
+	res.Description = max(free, TABLE_MIN_TEXT)
+	return res
+}
+
+// tableColumns are the texts of a row of the entry table.
+type tableColumns struct {
+	Date, Start, End, Pause, Project, Description string
+}
+
+// render lays out a row of the table. The header is aligned left and
+// without the separators of the entries.
+func (l tableLayout) render(c tableColumns, header bool) string {
+	arrow, open, close, colon := "→", "[", "]", ":"
+	if header {
+		arrow, open, close, colon = " ", " ", " ", " "
+	}
+	var s string
+	if l.Date > 0 {
+		if header {
+			s += fmt.Sprintf("%-*s  ", l.Date, c.Date)
+		} else {
+			s += fmt.Sprintf("%*s  ", l.Date, c.Date)
+		}
+	}
+	if header {
+		s += fmt.Sprintf("%-8s %s %-8s ", c.Start, arrow, c.End)
+	} else {
+		s += fmt.Sprintf("%8s %s %-8s ", c.Start, arrow, c.End)
+	}
+	if l.Pause > 0 {
+		s += fmt.Sprintf("%s%*s%s ", open, l.Pause, c.Pause, close)
+	}
+	if l.Project > 0 {
+		if header {
+			s += fmt.Sprintf("%-*.*s %s  ", l.Project, l.Project, c.Project, colon)
+		} else {
+			s += fmt.Sprintf("%*.*s %s  ", l.Project, l.Project, c.Project, colon)
+		}
+	}
+	return s + fmt.Sprintf("%-*.*s", l.Description, l.Description, c.Description)
+}
+
+// truncates reports whether the layout cuts off the project or description
+// of entry.
+func (l tableLayout) truncates(entry RowEntry) bool {
+	return lipgloss.Width(entry.Description) > l.Description || lipgloss.Width(entry.Project) > l.Project
+}
+
+func (r RowEntry) columns() tableColumns {
+	return tableColumns{
+		Date:        formatDate(r.Date, "Mon 02.01."),
+		Start:       r.StartClock().String(),
+		End:         r.describeEnd(),
+		Pause:       formatDuration(r.Pause),
+		Project:     r.Project,
+		Description: r.Description,
+	}
+}
+
+func (m Model) tableLayout() tableLayout {
+	return newTableLayout(m.width)
+}
+
+// editIndent returns the indentation of the inputs of the edited entry, which
+// start below the start time.
+func (m Model) editIndent() int {
+	if layout := m.tableLayout(); layout.Date > 0 {
+		return TABLE_INDENT + layout.Date + 2
+	}
+	return TABLE_INDENT
+}
+
+// viewDetails shows the selected entry in full if the table cuts it off or
+// it has a note.
+func (m Model) viewDetails() string {
+	todaysEntries := *m.getCurrentDayEntries()
+	if m.editActive || m.currentSelectedRow >= len(todaysEntries) {
+		return ""
+	}
+	entry := todaysEntries[m.currentSelectedRow]
+	if !m.tableLayout().truncates(entry) && entry.Note == "" {
+		return ""
+	}
+
+	wrap := lipgloss.NewStyle().Width(max(m.width-TABLE_INDENT*2, TABLE_MIN_TEXT))
+	lines := []string{tr("Project") + ": " + strings.TrimSpace(fmt.Sprintf("%s %s (%s)", entry.ProjectNr, entry.Project, entry.Customer))}
+	if entry.Description != "" {
+		lines = append(lines, wrap.Render(tr("Description")+": "+entry.Description))
+	}
+	if entry.Note != "" {
+		lines = append(lines, wrap.Render(tr("Note")+": "+strings.ReplaceAll(entry.Note, "\n", "; ")))
+	}
+	return lipgloss.NewStyle().PaddingLeft(TABLE_INDENT).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTableLayout(t *testing.T) {
+	date := time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)
+	entry := RowEntry{
+		Date:        date,
+		Start:       Clock(8 * 60).On(date),
+		End:         Clock(16*60 + 30).On(date),
+		Pause:       30 * time.Minute,
+		Project:     "Customer portal relaunch",
+		Description: "Migrated the order history to the new API and reviewed the pull requests of the team",
+	}
+
+	for _, width := range []int{160, 100, 70, 40} {
+		layout := newTableLayout(width)
+		row := layout.render(entry.columns(), false)
+		if w := TABLE_INDENT + lipgloss.Width(row); w > width {
+			t.Errorf("width %d: row of width %d doesn't fit: %q", width, w, row)
+		}
+		header := layout.render(tableColumns{Start: "Start", End: "End", Description: "Description"}, true)
+		if lipgloss.Width(header) != lipgloss.Width(row) {
+			t.Errorf("width %d: header and rows aren't aligned", width)
+		}
+		if got := layout.Date > 0; got != (width >= 120) {
+			t.Errorf("width %d: unexpected date column %d", width, layout.Date)
+		}
+		if width == 40 && (layout.Pause > 0 || layout.Project > 0) {
+			t.Errorf("expected only the times and description on narrow terminals, got %+v", layout)
+		}
+	}
+	if layout := newTableLayout(160); !strings.Contains(layout.render(entry.columns(), false), entry.Project) {
+		t.Errorf("expected the full project on wide terminals")
+	}
+
+	if s := entry.View(); !strings.HasPrefix(s, "Tue 07.01.     08:00 → 16:30    [     0:30] Customer portal rela :  ") {
+		t.Errorf("unexpected entry %q", s)
+	}
+}
+
+func TestDetailPane(t *testing.T) {
+	if err := SetTheme(DEFAULT_THEME); err != nil {
+		t.Fatal(err)
+	}
+	entries := newBulkTestEntries()
+	entries.Entries[0][6][1].Description = "A description much longer than the column for descriptions of a narrow terminal"
+	m := Model{
+		datepicker:         DatePicker{currentDay: time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)},
+		entryList:          entries,
+		styles:             newStyles(nil),
+		width:              60,
+		currentSelectedRow: 1,
+	}
+	details := m.viewDetails()
+	if !strings.Contains(strings.Join(strings.Fields(details), " "), entries.Entries[0][6][1].Description) {
+		t.Errorf("expected the detail pane to show the whole description, got %q", details)
+	}
+	for _, line := range strings.Split(details, "\n") {
+		if lipgloss.Width(line) > m.width {
+			t.Errorf("line of the detail pane is wider than the terminal: %q", line)
+		}
+	}
+	m.currentSelectedRow = 0
+	m.width = 200
+	if details := m.viewDetails(); details != "" {
+		t.Errorf("expected no detail pane for entries shown in full, got %q", details)
+	}
+}
@@ -22,7 +22,7 @@ func NewLoadingScreen(resultChan chan tea.Model) LoadingScreen {
   
   return LoadingScreen{
     spinner: s,
-    status: "Loading sheet...",
+    status: tr("Loading sheet..."),
     loadResult: resultChan,
   }
 }
@@ -36,9 +36,9 @@ func (l LoadingScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd){
   select {
   case model, ok := <- l.loadResult:
     if ok {
-      return model, nil
+      return model, model.Init()
     }
-    l.status = "Failed to load data!"
+    l.status = tr("Failed to load data!")
   default:
     // pass
   }
@@ -60,6 +60,6 @@ func (l LoadingScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd){
 }
 
 func (l LoadingScreen) View() string {
-  s := "Loading... " + l.spinner.View() + "\n"
+  s := tr("Loading...") + " " + l.spinner.View() + "\n"
   return s
 }
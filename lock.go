@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf16"
+)
+
+// LockInfo describes who holds the lock of a workbook.
+type LockInfo struct {
+	User  string
+	Host  string
+	PID   int
+	Since time.Time
+	File  string `json:"-"`
+}
+
+func (l LockInfo) String() string {
+	s := l.User
+	if l.Host != "" {
+		s += "@" + l.Host
+	}
+	if !l.Since.IsZero() {
+		s += " " + tr("since") + " " + l.Since.Format("02.01.2006 15:04")
+	}
+	return s + " (" + filepath.Base(l.File) + ")"
+}
+
+// WorkbookLock is held while we edit a workbook.
+type WorkbookLock struct {
+	files []string
+}
+
+// officeLockPath returns the owner file Excel creates next to an open
+// workbook.
+func officeLockPath(excelFileName string) string {
+	dir, name := filepath.Split(excelFileName)
+	return filepath.Join(dir, "~$"+name)
+}
+
+// libreOfficeLockPath returns the lock file LibreOffice creates next to an
+// open workbook.
+func libreOfficeLockPath(excelFileName string) string {
+	dir, name := filepath.Split(excelFileName)
+	return filepath.Join(dir, ".~lock."+name+"#")
+}
+
+func ownLockPath(excelFileName string) string {
+	dir, name := filepath.Split(excelFileName)
+	return filepath.Join(dir, "."+name+".lock")
+}
+
+func currentLockInfo() LockInfo {
+	info := LockInfo{PID: os.Getpid(), Since: time.Now()}
+	if u, err := user.Current(); err == nil {
+		info.User = u.Username
+	}
+	info.Host, _ = os.Hostname()
+	return info
+}
+
+// encodeOfficeLock creates the content of an owner file as written by
+// Microsoft Office: the user name as ANSI and as UTF-16, padded to 162 bytes.
+func encodeOfficeLock(userName string) []byte {
+	if len(userName) > 52 {
+		userName = userName[:52]
+	}
+	buf := make([]byte, 0, 162)
+	buf = append(buf, byte(len(userName)))
+	buf = append(buf, userName...)
+	for len(buf) < 54 {
+		buf = append(buf, ' ')
+	}
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(userName)))
+	for _, c := range utf16.Encode([]rune(userName)) {
+		buf = binary.LittleEndian.AppendUint16(buf, c)
+	}
+	for len(buf) < 162 {
+		buf = append(buf, ' ', 0)
+	}
+	return buf[:162]
+}
+
+func decodeOfficeLock(data []byte) string {
+	if len(data) == 0 || int(data[0]) >= len(data) {
+		return ""
+	}
+	return strings.TrimSpace(string(data[1 : 1+int(data[0])]))
+}
+
+// decodeLibreOfficeLock parses "name,user,host,date,profile;", the name
+// being the one configured in LibreOffice and the user the one of the system.
+func decodeLibreOfficeLock(data []byte) LockInfo {
+	fields := strings.Split(strings.TrimSuffix(strings.TrimSpace(string(data)), ";"), ",")
+	var info LockInfo
+	if len(fields) > 0 {
+		info.User = fields[0]
+	}
+	if len(fields) > 1 && info.User == "" {
+		info.User = fields[1]
+	}
+	if len(fields) > 2 {
+		info.Host = fields[2]
+	}
+	if len(fields) > 3 {
+		info.Since, _ = time.ParseInLocation("02.01.2006 15:04", fields[3], time.Local)
+	}
+	return info
+}
+
+// processAlive reports whether a process of this host is still running.
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// CheckLock returns who holds the lock of the workbook or nil if it isn't
+// locked by anyone else. Our own stale locks are ignored.
+func CheckLock(excelFileName string) (*LockInfo, error) {
+	me := currentLockInfo()
+
+	if data, err := os.ReadFile(ownLockPath(excelFileName)); err == nil {
+		var info LockInfo
+		if err := json.Unmarshal(data, &info); err != nil {
+			slog.Warn("Ignoring invalid lock file", "file", ownLockPath(excelFileName), "error", err)
+		} else if info.Host == me.Host && !processAlive(info.PID) {
+			slog.Info("Removing stale lock files", "file", ownLockPath(excelFileName), "pid", info.PID)
+			os.Remove(ownLockPath(excelFileName))
+			if data, err := os.ReadFile(officeLockPath(excelFileName)); err == nil && decodeOfficeLock(data) == info.User {
+				os.Remove(officeLockPath(excelFileName))
+			}
+		} else {
+			info.File = ownLockPath(excelFileName)
+			return &info, nil
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, path := range []string{officeLockPath(excelFileName), libreOfficeLockPath(excelFileName)} {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		var info LockInfo
+		if path == officeLockPath(excelFileName) {
+			info.User = decodeOfficeLock(data)
+		} else {
+			info = decodeLibreOfficeLock(data)
+		}
+		if stat, err := os.Stat(path); err == nil && info.Since.IsZero() {
+			info.Since = stat.ModTime()
+		}
+		info.File = path
+		return &info, nil
+	}
+	return nil, nil
+}
+
+// AcquireLock creates our own and an Office compatible lock file for the
+// workbook. If another instance created its lock file since CheckLock, the
+// error wraps fs.ErrExist.
+func AcquireLock(excelFileName string) (*WorkbookLock, error) {
+	info := currentLockInfo()
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &WorkbookLock{}
+	ownLock, err := os.OpenFile(ownLockPath(excelFileName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not create lock file: %w", err)
+	}
+	_, err = ownLock.Write(data)
+	if closeErr := ownLock.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(ownLockPath(excelFileName))
+		return nil, fmt.Errorf("could not write lock file: %w", err)
+	}
+	lock.files = append(lock.files, ownLockPath(excelFileName))
+
+	// an existing owner file belongs to someone else, so only create one if
+	// there is none yet
+	officeLock, err := os.OpenFile(officeLockPath(excelFileName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err == nil {
+		officeLock.Write(encodeOfficeLock(info.User))
+		officeLock.Close()
+		lock.files = append(lock.files, officeLockPath(excelFileName))
+	} else {
+		slog.Warn("Could not create office lock file", "file", officeLockPath(excelFileName), "error", err)
+	}
+	return lock, nil
+}
+
+// Release removes all lock files created by AcquireLock.
+func (l *WorkbookLock) Release() {
+	if l == nil {
+		return
+	}
+	for _, file := range l.files {
+		if err := os.Remove(file); err != nil {
+			slog.Error("Could not remove lock file", "file", file, "error", err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOfficeLockRoundTrip(t *testing.T) {
+	data := encodeOfficeLock("Max Mustermann")
+	if len(data) != 162 {
+		t.Errorf("expected owner file of 162 bytes, got %d", len(data))
+	}
+	if name := decodeOfficeLock(data); name != "Max Mustermann" {
+		t.Errorf("expected to read back user name, got %q", name)
+	}
+}
+
+func TestAcquireLock(t *testing.T) {
+	workbook := filepath.Join(t.TempDir(), "hours.xlsx")
+
+	if holder, err := CheckLock(workbook); err != nil || holder != nil {
+		t.Fatalf("expected unlocked workbook, got %v (%v)", holder, err)
+	}
+
+	lock, err := AcquireLock(workbook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(officeLockPath(workbook)); err != nil {
+		t.Errorf("expected office owner file to be created: %v", err)
+	}
+	holder, err := CheckLock(workbook)
+	if err != nil || holder == nil || holder.PID != os.Getpid() {
+		t.Errorf("expected workbook to be locked by this process, got %v (%v)", holder, err)
+	}
+
+	lock.Release()
+	if holder, err := CheckLock(workbook); err != nil || holder != nil {
+		t.Errorf("expected lock to be released, got %v (%v)", holder, err)
+	}
+
+	os.WriteFile(libreOfficeLockPath(workbook), []byte("Erika,erika,workstation,19.10.2026 08:30,file:///home/erika/.config/libreoffice/4;"), 0644)
+	holder, err = CheckLock(workbook)
+	if err != nil || holder == nil || holder.User != "Erika" || holder.Host != "workstation" || holder.Since.Hour() != 8 {
+		t.Errorf("expected LibreOffice lock to be honoured, got %v (%v)", holder, err)
+	}
+	// without a name configured in LibreOffice the user of the system is used
+	if info := decodeLibreOfficeLock([]byte(",erika,workstation,19.10.2026 08:30,file:///home/erika/.config/libreoffice/4;")); info.User != "erika" || info.Host != "workstation" {
+		t.Errorf("unexpected user %q and host %q", info.User, info.Host)
+	}
+}
+
+func TestAcquireLockIsExclusive(t *testing.T) {
+	workbook := filepath.Join(t.TempDir(), "hours.xlsx")
+	lock, err := AcquireLock(workbook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+
+	// a second instance which checked the lock before the first one took it
+	if second, err := AcquireLock(workbook); !errors.Is(err, fs.ErrExist) {
+		second.Release()
+		t.Errorf("expected the second lock to fail with fs.ErrExist, got %v", err)
+	}
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
+	"strings"
 
+	tint "github.com/lrstanley/bubbletint"
 	"github.com/xuri/excelize/v2"
 )
 
@@ -16,14 +21,47 @@ func main() {
 		inputfile   string
 		outputfile  string
 		debugoutput bool
+		configfile  string
+		readonly    bool
+		dryrun      bool
+		compat      string
+		lang        string
+		theme       string
+		keyPreset   string
+
+		billCustomer string
+		billFrom     string
+		billTo       string
+		billHTML     string
+		billXLSX     string
 	)
 
 	flag.StringVar(&inputfile, "in", "test.xlsx", "Excel file to work with")
 	flag.StringVar(&outputfile, "out", "out.xlsx", "File to save the results to")
 	flag.BoolVar(&debugoutput, "debug", false, "Decides whether debug output should be logged")
+	flag.StringVar(&configfile, "config", "", "JSON file with additional configuration")
+	flag.BoolVar(&readonly, "readonly", false, "Open the workbook without locking it, saving is disabled")
+	flag.StringVar(&lang, "lang", "", "Language of the user interface: en or de (default from LANG)")
+	flag.StringVar(&theme, "theme", "", "Colour theme from the bubbletint registry, \"list\" shows all themes")
+	flag.StringVar(&keyPreset, "keys", "", "Preset of key bindings: default, vim or arrows")
+	flag.StringVar(&compat, "compat", "", "Application the saved workbook is opened with: excel or libreoffice")
+	flag.BoolVar(&dryrun, "dry-run", false, "Print the changes saving the entries would make to the workbook and exit")
+
+	flag.StringVar(&billCustomer, "bill", "", "Create a billing statement for this customer instead of starting the editor")
+	flag.StringVar(&billFrom, "bill-from", "", "First day of the billing period (YYYY-MM-DD)")
+	flag.StringVar(&billTo, "bill-to", "", "Last day of the billing period (YYYY-MM-DD)")
+	flag.StringVar(&billHTML, "bill-html", "", "HTML file to write the billing statement to")
+	flag.StringVar(&billXLSX, "bill-xlsx", "", "Workbook to write the billing statement to")
 
 	flag.Parse()
 
+	if theme == "list" {
+		for _, t := range tint.DefaultTints() {
+			fmt.Printf("%-30s %s\n", t.ID(), t.DisplayName())
+		}
+		return
+	}
+
 	// Create debug output
 	var debug_file io.Writer
 	debug_file, err := os.OpenFile("./run.log", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
@@ -58,8 +96,161 @@ func main() {
 		ROW_ID_ENTRY_START:  6, // sixth row contains first entries
 		OutputFile:          outputfile,
 		ProjectNumbersSheet: "Projektnummern",
+		Currency:            "EUR",
+		BillingRoundingMode: "up",
+		SummarySheet:        "Gesamt",
+		SummaryFirstRow:     4,
+		SummaryColumns:      map[string]string{"F": "J", "G": "K", "H": "L"}, // hours, vacation, sickness
+	}
+
+	if configfile != "" {
+		if err := LoadConfigFile(configfile, &config); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	SetLanguage(detectLanguage(lang, config.Language))
+
+	if config.DurationDisplay != "" && !isDurationMode(config.DurationDisplay) {
+		fmt.Printf("Unknown duration display %q, use %s, %s or %s\n", config.DurationDisplay, DURATION_HHMM, DURATION_DECIMAL, DURATION_INDUSTRIAL)
+		os.Exit(1)
+	}
+	SetDurationMode(config.DurationDisplay)
+
+	if keyPreset != "" {
+		config.KeyPreset = keyPreset
+	}
+	if err := SetKeyBindings(config.KeyPreset, config.Keys); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if theme != "" {
+		config.Theme = theme
+	}
+	if err := SetTheme(config.Theme); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if config.StyleFile != "" {
+		styles, err := LoadStyleFile(config.StyleFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if config.Styles == nil {
+			config.Styles = make(map[string]StyleOverride)
+		}
+		for name, override := range styles {
+			config.Styles[name] = override
+		}
+	}
+
+	if compat != "" {
+		config.OutputCompatibility = compat
+	}
+	if config.OutputCompatibility != "" && config.OutputCompatibility != COMPAT_EXCEL && config.OutputCompatibility != COMPAT_LIBREOFFICE {
+		fmt.Printf("Unknown output compatibility %q, use %s or %s\n", config.OutputCompatibility, COMPAT_EXCEL, COMPAT_LIBREOFFICE)
+		os.Exit(1)
+	}
+
+	if config.OvernightMode != "" && config.OvernightMode != OVERNIGHT_MARK && config.OvernightMode != OVERNIGHT_SPLIT {
+		fmt.Printf("Unknown overnight mode %q, use %s or %s\n", config.OvernightMode, OVERNIGHT_MARK, OVERNIGHT_SPLIT)
+		os.Exit(1)
+	}
+
+	if err := config.Rounding.Validate(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for projectNr, policy := range config.ProjectRounding {
+		if err := policy.Validate(); err != nil {
+			fmt.Printf("Project %s: %s\n", projectNr, err)
+			os.Exit(1)
+		}
 	}
 
 	slog.Debug("Using config", "config", config)
-	Start(config)
+
+	// the billing export only reads the workbook, so it neither locks it nor
+	// touches the journal of an editor working on it
+	if billCustomer != "" {
+		config.ReadOnly = true
+		if err := RunBillingExport(config, billCustomer, billFrom, billTo, billHTML, billXLSX); err != nil {
+			fmt.Println(tr("Failed to create billing statement:"), err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	config.ReadOnly = readonly || dryrun
+	if !config.ReadOnly {
+		holder, err := CheckLock(inputfile)
+		if err != nil {
+			slog.Error("Could not check lock of workbook", "error", err)
+		}
+		if holder != nil {
+			fmt.Println(trf("%s is already opened by %s", inputfile, holder))
+			if !askYesNo(tr("Open it read-only?")) {
+				os.Exit(1)
+			}
+			config.ReadOnly = true
+		}
+	}
+	var lock *WorkbookLock
+	if !config.ReadOnly {
+		lock, err = AcquireLock(inputfile)
+		if errors.Is(err, fs.ErrExist) {
+			fmt.Println(tr("Locked meanwhile, opening it read-only"))
+			config.ReadOnly = true
+		} else if err != nil {
+			slog.Error("Could not lock workbook", "error", err)
+		}
+	}
+	// os.Exit skips deferred calls
+	exit := func(code int) {
+		lock.Release()
+		os.Exit(code)
+	}
+	defer lock.Release()
+
+	if pending, err := PendingJournal(inputfile); err != nil {
+		slog.Error("Could not read journal", "error", err)
+	} else if len(pending) > 0 && (!config.ReadOnly || dryrun) {
+		fmt.Printf("%s\n\n%s\n", tr("Found unsaved changes of a previous run:"), DescribeJournal(pending))
+		config.ReplayJournal = askYesNo(tr("Restore these changes?"))
+		if !config.ReplayJournal && !dryrun {
+			os.Remove(journalPath(inputfile))
+		}
+	}
+
+	if dryrun {
+		entryList := NewEntryList(config)
+		if config.ReplayJournal {
+			pending, _ := PendingJournal(inputfile)
+			entryList.ReplayJournal(pending)
+		}
+		changes, err := PreviewRowEntries(entryList.Sheets(), config)
+		if err != nil {
+			fmt.Println(tr("Could not preview changes:"), err)
+			exit(1)
+		}
+		fmt.Print(FormatDiff(changes))
+		return
+	}
+
+	if err := Start(config); err != nil {
+		fmt.Println("An error occurred: ", err)
+		exit(1)
+	}
+}
+
+// askYesNo asks a question on the terminal and reports whether it was
+// answered with yes.
+func askYesNo(question string) bool {
+	fmt.Printf("%s %s ", question, tr("[y/N]"))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes" || answer == "j" || answer == "ja"
 }
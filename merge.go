@@ -0,0 +1,225 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// MergeConflict describes an entry which was changed both in memory and in
+// the workbook on disk. Nil entries were deleted or didn't exist.
+type MergeConflict struct {
+	Date   time.Time
+	Base   *RowEntry
+	Ours   *RowEntry
+	Theirs *RowEntry
+}
+
+func entriesEqual(a, b *RowEntry) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Start.Equal(b.Start) && a.End.Equal(b.End) && a.Pause == b.Pause &&
+		a.ProjectNr == b.ProjectNr && a.Project == b.Project && a.Customer == b.Customer &&
+		a.Description == b.Description && a.Vacation == b.Vacation && a.Sickness == b.Sickness &&
+		a.Note == b.Note
+}
+
+func daysEqual(a, b []RowEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !entriesEqual(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// copyEntries returns a deep copy of all entries.
+func copyEntries(entries [][][]RowEntry) [][][]RowEntry {
+	res := make([][][]RowEntry, len(entries))
+	for i, month := range entries {
+		res[i] = make([][]RowEntry, len(month))
+		for j, day := range month {
+			res[i][j] = append([]RowEntry(nil), day...)
+		}
+	}
+	return res
+}
+
+// overlap returns how long the times of a and b overlap.
+func overlap(a, b *RowEntry) time.Duration {
+	start, end := a.Start, a.End
+	if b.Start.After(start) {
+		start = b.Start
+	}
+	if b.End.Before(end) {
+		end = b.End
+	}
+	return max(end.Sub(start), 0)
+}
+
+// identifyEntries returns the entries of one side of a merge by their
+// identity: the index of the base entry they derive from or, for added
+// entries, added plus their own index. An entry derives from an equal base
+// entry, else from one with the same start, else from the one its times
+// overlap most.
+func identifyEntries(base, side []RowEntry, added int) map[int]*RowEntry {
+	res := make(map[int]*RowEntry, len(side))
+	matched := make([]bool, len(side))
+	// match assigns every unmatched entry to the best unused base entry
+	// according to score, ignoring scores of 0.
+	match := func(score func(b, s *RowEntry) time.Duration) {
+		for i := range side {
+			best, bestScore := -1, time.Duration(0)
+			for j := range base {
+				if _, used := res[j]; used || matched[i] {
+					continue
+				}
+				if s := score(&base[j], &side[i]); s > bestScore {
+					best, bestScore = j, s
+				}
+			}
+			if best >= 0 {
+				res[best], matched[i] = &side[i], true
+			}
+		}
+	}
+	match(func(b, s *RowEntry) time.Duration {
+		if entriesEqual(b, s) {
+			return 1
+		}
+		return 0
+	})
+	match(func(b, s *RowEntry) time.Duration {
+		if b.Start.Equal(s.Start) {
+			return 1
+		}
+		return 0
+	})
+	match(overlap)
+	for i := range side {
+		if !matched[i] {
+			res[added+i] = &side[i]
+		}
+	}
+	return res
+}
+
+// mergeDay performs a three-way merge of the entries of a single day.
+// Conflicting entries are resolved in favour of ours.
+func mergeDay(date time.Time, base, ours, theirs []RowEntry) ([]RowEntry, []MergeConflict) {
+	switch {
+	case daysEqual(ours, theirs), daysEqual(base, theirs):
+		return ours, nil
+	case daysEqual(base, ours):
+		return theirs, nil
+	}
+
+	baseIDs := make(map[int]*RowEntry, len(base))
+	for i := range base {
+		baseIDs[i] = &base[i]
+	}
+	ourIDs := identifyEntries(base, ours, len(base))
+	theirIDs := identifyEntries(base, theirs, len(base)+len(ours))
+	ids := make(map[int]bool)
+	for _, m := range []map[int]*RowEntry{baseIDs, ourIDs, theirIDs} {
+		for id := range m {
+			ids[id] = true
+		}
+	}
+
+	var merged []RowEntry
+	var conflicts []MergeConflict
+	for id := range ids {
+		b, o, t := baseIDs[id], ourIDs[id], theirIDs[id]
+		var res *RowEntry
+		switch {
+		case entriesEqual(o, t), entriesEqual(b, t):
+			res = o
+		case entriesEqual(b, o):
+			res = t
+		default:
+			res = o
+			conflict := MergeConflict{Date: date, Base: copyEntry(b), Ours: copyEntry(o), Theirs: copyEntry(t)}
+			for _, entry := range []*RowEntry{o, t, b} {
+				if entry != nil {
+					conflict.Date = entry.Date
+					break
+				}
+			}
+			conflicts = append(conflicts, conflict)
+		}
+		if res != nil {
+			merged = append(merged, *res)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Start.Before(merged[j].Start) })
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflictStart(conflicts[i]).Before(conflictStart(conflicts[j]))
+	})
+	return merged, conflicts
+}
+
+func copyEntry(entry *RowEntry) *RowEntry {
+	if entry == nil {
+		return nil
+	}
+	res := *entry
+	return &res
+}
+
+func conflictStart(c MergeConflict) time.Time {
+	for _, entry := range []*RowEntry{c.Ours, c.Theirs, c.Base} {
+		if entry != nil {
+			return entry.Start
+		}
+	}
+	return c.Date
+}
+
+// MergeEntries merges the changes between base and theirs into ours. Entries
+// changed on both sides are kept as in ours and returned as conflicts.
+func MergeEntries(base, ours, theirs [][][]RowEntry) ([][][]RowEntry, []MergeConflict) {
+	merged := make([][][]RowEntry, len(ours))
+	var conflicts []MergeConflict
+	for i := range ours {
+		merged[i] = make([][]RowEntry, len(ours[i]))
+		for j := range ours[i] {
+			date := time.Date(0, time.Month(i+1), j+1, 0, 0, 0, 0, time.UTC)
+			dayConflicts := []MergeConflict(nil)
+			merged[i][j], dayConflicts = mergeDay(date, dayAt(base, i, j), ours[i][j], dayAt(theirs, i, j))
+			conflicts = append(conflicts, dayConflicts...)
+		}
+	}
+	return merged, conflicts
+}
+
+func dayAt(entries [][][]RowEntry, month, day int) []RowEntry {
+	if month >= len(entries) || day >= len(entries[month]) {
+		return nil
+	}
+	return entries[month][day]
+}
+
+// ResolveConflictWithTheirs replaces the entry kept for a conflict by the one
+// of the workbook on disk.
+func (e *EntryList) ResolveConflictWithTheirs(c MergeConflict) {
+	day := e.dayEntries(c.Date)
+	if day == nil {
+		return
+	}
+	if c.Ours != nil {
+		for i := range *day {
+			if entriesEqual(&(*day)[i], c.Ours) {
+				*day = append((*day)[:i], (*day)[i+1:]...)
+				break
+			}
+		}
+	}
+	if c.Theirs != nil {
+		*day = append(*day, *c.Theirs)
+		sort.SliceStable(*day, func(i, j int) bool { return (*day)[i].Start.Before((*day)[j].Start) })
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeDay(t *testing.T) {
+	date := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	entry := func(h int, description string) RowEntry {
+		return RowEntry{Date: date, Start: date.Add(time.Duration(h) * time.Hour), End: date.Add(time.Duration(h+1) * time.Hour), Description: description}
+	}
+	base := []RowEntry{entry(8, "a"), entry(9, "b"), entry(10, "c")}
+
+	// ours changes 8, theirs deletes 10 and adds 11: no conflicts
+	ours := []RowEntry{entry(8, "a (mine)"), entry(9, "b"), entry(10, "c")}
+	theirs := []RowEntry{entry(8, "a"), entry(9, "b"), entry(11, "d")}
+	merged, conflicts := mergeDay(date, base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+	expected := []RowEntry{entry(8, "a (mine)"), entry(9, "b"), entry(11, "d")}
+	if !daysEqual(merged, expected) {
+		t.Errorf("unexpected merge result %+v", merged)
+	}
+
+	// both change 9 differently
+	ours = []RowEntry{entry(8, "a"), entry(9, "b (mine)"), entry(10, "c")}
+	theirs = []RowEntry{entry(8, "a"), entry(9, "b (theirs)"), entry(10, "c")}
+	merged, conflicts = mergeDay(date, base, ours, theirs)
+	if len(conflicts) != 1 || conflicts[0].Ours.Description != "b (mine)" || conflicts[0].Theirs.Description != "b (theirs)" {
+		t.Fatalf("expected a conflict for the 9 o'clock entry, got %+v", conflicts)
+	}
+	if !daysEqual(merged, ours) {
+		t.Errorf("expected conflicts to be resolved with ours, got %+v", merged)
+	}
+
+	entries := make([][][]RowEntry, 12)
+	entries[0] = make([][]RowEntry, 31)
+	entries[0][5] = merged
+	entryList := EntryList{Entries: entries}
+	entryList.ResolveConflictWithTheirs(conflicts[0])
+	if !daysEqual(entryList.Entries[0][5], theirs) {
+		t.Errorf("expected conflict to be resolved with theirs, got %+v", entryList.Entries[0][5])
+	}
+}
+
+func TestMergeDayMovedEntry(t *testing.T) {
+	date := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	entry := func(start, end int, description string) RowEntry {
+		return RowEntry{Date: date, Start: Clock(start).On(date), End: Clock(end).On(date), Description: description}
+	}
+	base := []RowEntry{entry(8*60, 9*60, "a"), entry(10*60, 11*60, "b")}
+
+	// ours moves the start of a, theirs adds c: a is still the same entry
+	ours := []RowEntry{entry(8*60+30, 9*60, "a"), entry(10*60, 11*60, "b")}
+	theirs := []RowEntry{entry(8*60, 9*60, "a"), entry(10*60, 11*60, "b"), entry(14*60, 15*60, "c")}
+	merged, conflicts := mergeDay(date, base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+	if expected := append(ours, theirs[2]); !daysEqual(merged, expected) {
+		t.Errorf("unexpected merge result %+v", merged)
+	}
+
+	// ours moves the start of a, theirs changes its description
+	theirs = []RowEntry{entry(8*60, 9*60, "a (theirs)"), entry(10*60, 11*60, "b")}
+	merged, conflicts = mergeDay(date, base, ours, theirs)
+	if len(conflicts) != 1 || conflicts[0].Ours.Description != "a" || conflicts[0].Theirs.Description != "a (theirs)" {
+		t.Fatalf("expected a conflict for the moved entry, got %+v", conflicts)
+	}
+	if !daysEqual(merged, ours) {
+		t.Errorf("expected only our version of the moved entry, got %+v", merged)
+	}
+}
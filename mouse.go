@@ -0,0 +1,139 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Arrows next to the current date switching the day when clicked.
+const (
+	DATE_ARROW_PREV = "◀"
+	DATE_ARROW_NEXT = "▶"
+)
+
+// CALENDAR_CELL_WIDTH is the width of a day in the calendar, see viewCalendar.
+const CALENDAR_CELL_WIDTH = 4
+
+func (m Model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.quitConfirmActive || len(m.conflicts) > 0 || m.savePreviewActive || m.promptActive {
+		return m, nil
+	}
+
+	wheel := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		wheel = -1
+	case tea.MouseButtonWheelDown:
+		wheel = 1
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+	default:
+		return m, nil
+	}
+
+	top := strings.Count(m.viewTop(), "\n")
+	switch {
+	case m.themePickerActive:
+		first, last := m.themePickerWindow()
+		if wheel != 0 {
+			m.previewTheme(m.themeIndex + wheel)
+		} else if line := msg.Y - top - lipgloss.Height(m.viewThemePickerHeader()); line >= 0 && line < last-first {
+			m.previewTheme(first + line)
+		}
+	case m.searchListActive:
+		first, last := m.searchResultsWindow()
+		if wheel != 0 {
+			m.searchIndex = helperMod(m.searchIndex+wheel, len(m.searchResults))
+		} else if line := msg.Y - top - lipgloss.Height(m.viewSearchResultsHeader()); line >= 0 && line < last-first {
+			m.searchIndex = first + line
+		}
+	case wheel != 0:
+		m.scrollEntries(wheel)
+	default:
+		m.clickMainScreen(msg.X, msg.Y, top)
+	}
+	return m, nil
+}
+
+// scrollEntries moves the selection through the entries of the current day,
+// or through the matching projects while editing.
+func (m *Model) scrollEntries(offset int) {
+	if m.editActive {
+		if m.focusedIndex == 4 && len(m.potentialProjects) > 0 {
+			m.projectNumberIndex = helperMod(m.projectNumberIndex+offset, len(m.potentialProjects))
+		}
+		return
+	}
+	if n := len(*m.getCurrentDayEntries()); n > 0 {
+		m.currentSelectedRow = helperMod(m.currentSelectedRow+offset, n)
+	}
+}
+
+// clickMainScreen handles a click on the date arrows, a day of the calendar,
+// an entry, an edit input or a matching project. top is the line of the
+// table header, the entries below it are the visible part of the table.
+func (m *Model) clickMainScreen(x, y, top int) {
+	lines := strings.Split(m.viewTop(), "\n")
+	dateLine := -1
+	for i, line := range lines {
+		if strings.Contains(line, DATE_ARROW_PREV) {
+			dateLine = i
+			break
+		}
+	}
+
+	switch {
+	case y == dateLine && !m.editActive:
+		line := lines[dateLine]
+		prev := lipgloss.Width(line[:strings.Index(line, DATE_ARROW_PREV)])
+		next := lipgloss.Width(line[:strings.Index(line, DATE_ARROW_NEXT)])
+		if x >= prev-1 && x <= prev+1 {
+			m.changeDay(-1)
+		} else if x >= next-1 && x <= next+1 {
+			m.changeDay(1)
+		}
+	case dateLine >= 0 && y > dateLine+3 && y < top && !m.editActive:
+		// below the title and the weekdays of the calendar
+		current := m.datepicker.currentDay
+		first := current.AddDate(0, 0, 1-current.Day())
+		cell := (y-dateLine-4)*7 + x/CALENDAR_CELL_WIDTH - helperMod(int(first.Weekday())-1, 7)
+		if day := first.AddDate(0, 0, cell); cell >= 0 && day.Month() == first.Month() && x/CALENDAR_CELL_WIDTH < 7 {
+			m.changeDay(day.Day() - current.Day())
+		}
+	case y >= top:
+		table, _ := m.visibleEntryTable()
+		row := y - top - lipgloss.Height(m.viewTableHeader())
+		if row < 0 || row >= len(table) {
+			return
+		}
+		switch line := table[row]; line.kind {
+		case LINE_ENTRY:
+			if !m.editActive {
+				m.currentSelectedRow = line.index
+			}
+		case LINE_INPUTS:
+			m.focusInputAt(x)
+		case LINE_PROJECT:
+			m.projectNumberIndex = line.index
+			trySettingCurrentSelectedProjectNr(m)
+		}
+	}
+}
+
+// focusInputAt focuses the edit input at column x of the edit row.
+func (m *Model) focusInputAt(x int) {
+	// see ViewAsEdit, tabs are rendered as 4 spaces
+	start := m.editIndent()
+	for i := range m.textInputs {
+		width := lipgloss.Width(m.styles["inputField"].Render(m.textInputs[i].View())) + 4
+		if x >= start && x < start+width {
+			m.focusedIndex = i
+			return
+		}
+		start += width
+	}
+}
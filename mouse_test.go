@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestMouse(t *testing.T) {
+	if err := SetTheme(DEFAULT_THEME); err != nil {
+		t.Fatal(err)
+	}
+	var m tea.Model = Model{
+		datepicker: DatePicker{currentDay: time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)},
+		entryList:  newBulkTestEntries(),
+		styles:     newStyles(nil),
+		modified:   make(map[time.Time]bool),
+		numColumns: 6,
+	}
+	click := func(x, y int) {
+		m, _ = m.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	}
+	model := func() Model { return m.(Model) }
+
+	top := strings.Count(model().viewTop(), "\n")
+	firstEntry := top + lipgloss.Height(model().viewTableHeader())
+	click(10, firstEntry+2)
+	if model().currentSelectedRow != 2 {
+		t.Errorf("expected the third entry to be selected, got %d", model().currentSelectedRow)
+	}
+	m, _ = m.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	if model().currentSelectedRow != 0 {
+		t.Errorf("expected the wheel to wrap around to the first entry, got %d", model().currentSelectedRow)
+	}
+
+	var dateLine int
+	for i, line := range strings.Split(model().viewTop(), "\n") {
+		if strings.Contains(line, DATE_ARROW_NEXT) {
+			dateLine = i
+			click(lipgloss.Width(line[:strings.Index(line, DATE_ARROW_NEXT)]), i)
+		}
+	}
+	if day := model().datepicker.currentDay.Day(); day != 8 {
+		t.Errorf("expected the arrow to switch to the 8th, got %d", day)
+	}
+
+	// January 2025 starts on a Wednesday, the 20th is the first day of the fourth week
+	click(1, dateLine+4+3)
+	if day := model().datepicker.currentDay.Day(); day != 20 {
+		t.Errorf("expected a click on the calendar to switch to the 20th, got %d", day)
+	}
+	click(CALENDAR_CELL_WIDTH*5, dateLine+4)
+	if day := model().datepicker.currentDay.Day(); day != 4 {
+		t.Errorf("expected a click on the calendar to switch to the 4th, got %d", day)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// How the writer stores entries ending on the following day.
+const (
+	OVERNIGHT_SPLIT = "split" // two rows, one on each day, split at midnight
+	OVERNIGHT_MARK  = "mark"  // one row with an end time after 24:00
+)
+
+// HasEnd reports whether the end of the entry was filled in. A missing end is
+// read as midnight before the start.
+func (r RowEntry) HasEnd() bool {
+	return !r.End.Before(r.Start)
+}
+
+// IsOvernight reports whether the entry ends after midnight of its date.
+func (r RowEntry) IsOvernight() bool {
+	return r.EndClock() > MINUTES_PER_DAY
+}
+
+// Parts splits an overnight entry at midnight into one entry for each day.
+// The pause is attributed to the first day as far as it was worked there.
+func (r RowEntry) Parts() []RowEntry {
+	if !r.IsOvernight() {
+		return []RowEntry{r}
+	}
+	first, second := r, r
+	midnight := Clock(MINUTES_PER_DAY).On(r.Date)
+
+	first.End = midnight
+	first.Pause = min(r.Pause, first.End.Sub(first.Start))
+	first.Breaks, second.Breaks = nil, nil
+	for _, b := range r.Breaks {
+		if b.Start < MINUTES_PER_DAY {
+			first.Breaks = append(first.Breaks, Break{b.Start, min(b.End, MINUTES_PER_DAY)})
+		}
+		if b.End > MINUTES_PER_DAY {
+			second.Breaks = append(second.Breaks, Break{max(b.Start, MINUTES_PER_DAY) - MINUTES_PER_DAY, b.End - MINUTES_PER_DAY})
+		}
+	}
+	if len(r.Breaks) > 0 {
+		first.Pause = 0
+		for _, b := range first.Breaks {
+			first.Pause += b.Duration()
+		}
+	}
+
+	second.Date = toSheetDate(r.Date.AddDate(0, 0, 1))
+	second.Day = WEEKDAYS[int(second.Date.Weekday())]
+	second.Start = second.Date
+	second.Pause = r.Pause - first.Pause
+	second.RowIndex = 0
+	second.RawRow = nil
+	second.Styles = nil
+	second.Formulas = nil
+	return []RowEntry{first, second}
+}
+
+// DurationOn returns the part of the worked time of the entry falling on
+// date.
+func (r RowEntry) DurationOn(date time.Time) time.Duration {
+	var res time.Duration
+	for _, part := range r.Parts() {
+		if part.Date.Equal(toSheetDate(date)) {
+			res += part.Duration()
+		}
+	}
+	return res
+}
+
+// dayTotal returns the time worked on date by the entries of that day and
+// the overnight entries of the day before.
+func dayTotal(previous, day []RowEntry, date time.Time) time.Duration {
+	var res time.Duration
+	for _, entry := range append(append([]RowEntry{}, previous...), day...) {
+		res += entry.DurationOn(date)
+	}
+	return res
+}
+
+// splitOvernightEntries returns a copy of entries with all overnight entries
+// split at midnight. The part after midnight is moved to the following day,
+// which may be on the sheet of the next month.
+func splitOvernightEntries(entries map[string][][]RowEntry) map[string][][]RowEntry {
+	res := make(map[string][][]RowEntry, len(entries))
+	sheetOfMonth := make(map[time.Month]string)
+	for sheet, month := range entries {
+		res[sheet] = make([][]RowEntry, len(month))
+		for i, day := range month {
+			res[sheet][i] = append([]RowEntry(nil), day...)
+			if len(day) > 0 {
+				sheetOfMonth[day[0].Date.Month()] = sheet
+			}
+		}
+	}
+
+	for sheet, month := range entries {
+		for i, day := range month {
+			for _, entry := range day {
+				if !entry.IsOvernight() {
+					continue
+				}
+				parts := entry.Parts()
+				next := parts[1]
+				nextSheet, ok := sheetOfMonth[next.Date.Month()]
+				if !ok && next.Date.Month() == entry.Date.Month() {
+					nextSheet, ok = sheet, true
+				}
+				if !ok || next.Date.Year() != entry.Date.Year() || next.Date.Day() > len(res[nextSheet]) {
+					slog.Warn("Cannot split overnight entry, the following day is not loaded", "date", entry.Date)
+					continue
+				}
+				next.SheetName = nextSheet
+
+				today := res[sheet][i]
+				for j := range today {
+					if entriesEqual(&today[j], &entry) {
+						today[j] = parts[0]
+						break
+					}
+				}
+				nextDay := &res[nextSheet][next.Date.Day()-1]
+				*nextDay = append(*nextDay, next)
+				sort.SliceStable(*nextDay, func(a, b int) bool { return (*nextDay)[a].Start.Before((*nextDay)[b].Start) })
+			}
+		}
+	}
+	return res
+}
+
+// describeEnd renders the end of an entry, marking ends on the following day.
+func (r RowEntry) describeEnd() string {
+	if !r.HasEnd() {
+		return ""
+	}
+	if r.IsOvernight() {
+		return fmt.Sprintf("%s+1", r.EndClock()%MINUTES_PER_DAY)
+	}
+	return r.EndClock().String()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func overnightEntry(date time.Time, start, end Clock, pause time.Duration) RowEntry {
+	return RowEntry{Date: date, Day: WEEKDAYS[int(date.Weekday())], Start: start.On(date), End: end.On(date), Pause: pause, ProjectNr: "2024-1310"}
+}
+
+func TestOvernightParts(t *testing.T) {
+	date := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	entry := overnightEntry(date, 22*60, 26*60, 30*time.Minute)
+	if !entry.IsOvernight() || entry.describeEnd() != "02:00+1" {
+		t.Fatalf("expected an overnight entry, got end %s", entry.describeEnd())
+	}
+
+	parts := entry.Parts()
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if parts[0].EndClock() != MINUTES_PER_DAY || parts[0].Pause != 30*time.Minute {
+		t.Errorf("unexpected first part %s - %s, pause %s", parts[0].StartClock(), parts[0].EndClock(), parts[0].Pause)
+	}
+	if !parts[1].Date.Equal(date.AddDate(0, 0, 1)) || parts[1].StartClock() != 0 || parts[1].EndClock() != 2*60 || parts[1].Pause != 0 {
+		t.Errorf("unexpected second part on %s: %s - %s", parts[1].Date, parts[1].StartClock(), parts[1].EndClock())
+	}
+
+	if d := entry.DurationOn(date); d != 90*time.Minute {
+		t.Errorf("expected 1h30m before midnight, got %s", d)
+	}
+	next := date.AddDate(0, 0, 1)
+	morning := overnightEntry(next, 10*60, 12*60, 0)
+	if d := dayTotal([]RowEntry{entry}, []RowEntry{morning}, next); d != 4*time.Hour {
+		t.Errorf("expected 4h on the following day, got %s", d)
+	}
+}
+
+func TestSplitOvernightEntries(t *testing.T) {
+	date := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	entry := overnightEntry(date, 22*60, 26*60, 0)
+	entry.SheetName = "01"
+	january := make([][]RowEntry, 31)
+	january[30] = []RowEntry{entry}
+	february := make([][]RowEntry, 28)
+	february[0] = []RowEntry{overnightEntry(date.AddDate(0, 0, 1), 8*60, 12*60, 0)}
+
+	res := splitOvernightEntries(map[string][][]RowEntry{"01": january, "02": february})
+	if got := res["01"][30]; len(got) != 1 || got[0].EndClock() != MINUTES_PER_DAY {
+		t.Errorf("unexpected entries before midnight: %v", got)
+	}
+	got := res["02"][0]
+	if len(got) != 2 || got[0].EndClock() != 2*60 || got[0].SheetName != "02" {
+		t.Errorf("expected the part after midnight first on February 1st, got %v", got)
+	}
+	if len(january[30]) != 1 || !january[30][0].IsOvernight() {
+		t.Errorf("splitting modified the original entries")
+	}
+}
+
+func TestReadOvernightEntry(t *testing.T) {
+	row := []string{"45688", "Fr", "0.916666666666667", "0.0833333333333333", "", "2024-1310", "Portal", "ACME", "Deployment", "4"}
+	entry, err := ReadEntryFromRow(row, "01", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !entry.IsOvernight() || entry.EndClock() != 26*60 {
+		t.Errorf("expected the entry to end at 02:00 the next day, got %s", entry.EndClock())
+	}
+	if entry.Duration() != 4*time.Hour {
+		t.Errorf("expected 4h, got %s", entry.Duration())
+	}
+}
+
+func TestReadMissingEnd(t *testing.T) {
+	row := []string{"45688", "Fr", "0.333333333333333", "", "", "2024-1310", "Portal", "ACME", "Deployment", ""}
+	entry, err := ReadEntryFromRow(row, "01", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.HasEnd() || entry.IsOvernight() || entry.Duration() != 0 || entry.describeEnd() != "" {
+		t.Errorf("expected an empty end to stay missing, got %s → %q (%s)", entry.StartClock(), entry.describeEnd(), entry.Duration())
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	WriteRowEntry(f, "Sheet1", 4, entry)
+	if start, _ := f.GetCellValue("Sheet1", "C4"); start == "" {
+		t.Errorf("expected the start to be written")
+	}
+	if end, _ := f.GetCellValue("Sheet1", "D4"); end != "" {
+		t.Errorf("expected the end to stay empty, got %q", end)
+	}
+}
+
+func TestCheckWorkingTimeAct(t *testing.T) {
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	// 8:00 - 19:30 with a 30 minute break is 11h of work
+	long := []RowEntry{overnightEntry(date, 8*60, 19*60+30, 30*time.Minute)}
+	if violations := CheckWorkingTimeAct(nil, long, date); len(violations) != 2 {
+		t.Errorf("expected the daily maximum and the break to be violated, got %v", violations)
+	}
+
+	// a gap between entries counts as break
+	split := []RowEntry{overnightEntry(date, 8*60, 12*60, 0), overnightEntry(date, 12*60+30, 16*60+30, 0)}
+	if violations := CheckWorkingTimeAct(nil, split, date); len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+
+	// a night shift until 02:00 leaves too little rest before 08:00
+	previous := []RowEntry{overnightEntry(date.AddDate(0, 0, -1), 22*60, 26*60, 0)}
+	violations := CheckWorkingTimeAct(previous, split, date)
+	if len(violations) != 1 {
+		t.Fatalf("expected the rest period to be violated, got %v", violations)
+	}
+	if violations[0].Message != "Rest period of 6:00 is shorter than the required 11:00" {
+		t.Errorf("unexpected message %q", violations[0].Message)
+	}
+
+	// a vacation day after a workday is no work at all
+	vacation := []RowEntry{{Date: date, Start: date, End: date, Vacation: 8 * time.Hour}}
+	if violations := CheckWorkingTimeAct(split, vacation, date.AddDate(0, 0, 1)); len(violations) != 0 {
+		t.Errorf("expected no violations on a vacation day, got %v", violations)
+	}
+	sick := append([]RowEntry{{Date: date, Start: date, End: date, Sickness: 8 * time.Hour}}, split...)
+	if violations := CheckWorkingTimeAct(nil, sick, date); len(violations) != 0 {
+		t.Errorf("expected a sickness row not to count, got %v", violations)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	PROMPT_BULK   = "bulk"
+	PROMPT_SEARCH = "search"
+)
+
+// openPrompt shows a single line input below the entries. Its value is
+// handled by runPrompt once enter is pressed.
+func (m *Model) openPrompt(kind, prefix, placeholder string) tea.Cmd {
+	p := textinput.New()
+	p.Prompt = prefix
+	p.Placeholder = placeholder
+	p.Width = 50
+	m.prompt = p
+	m.promptKind = kind
+	m.promptActive = true
+	return m.prompt.Focus()
+}
+
+func (m *Model) closePrompt() {
+	m.promptActive = false
+	m.prompt.Blur()
+}
+
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closePrompt()
+		return m, nil
+	case tea.KeyEnter:
+		m.closePrompt()
+		m.runPrompt(m.promptKind, m.prompt.Value())
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) runPrompt(kind, value string) {
+	switch kind {
+	case PROMPT_BULK:
+		affected, err := m.entryList.ApplyBulkCommand(m.selection, value, m.projectNumbers)
+		if err != nil {
+			m.debugMessage = tr("Bulk edit failed:") + " " + err.Error()
+			return
+		}
+		m.debugMessage = trf("Applied %q to %d entries", value, affected)
+		if affected > 0 {
+			m.markModified(m.selection.Anchor.Date, m.selection.Cursor.Date)
+		}
+		m.visualActive = false
+		m.clampSelectedRow()
+	case PROMPT_SEARCH:
+		m.search(value)
+	case PROMPT_GOTO:
+		m.goToExpression(value)
+	}
+}
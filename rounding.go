@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// How a RoundingPolicy rounds the times of an entry.
+const (
+	ROUND_EXACT   = "exact"   // keep the exact minutes
+	ROUND_SPAN    = "span"    // round the start down and the end up
+	ROUND_NEAREST = "nearest" // round the worked time to the nearest increment
+)
+
+// When a RoundingPolicy is applied.
+const (
+	ROUND_ON_ENTRY  = "entry"  // to the times stored in the workbook when editing
+	ROUND_ON_REPORT = "report" // to the billable time of billing statements
+	ROUND_ON_BOTH   = "both"
+)
+
+// RoundingPolicy rounds the times of entries to an increment, e.g. to bill
+// customers in 15 minute increments while the sheets keep exact minutes.
+type RoundingPolicy struct {
+	Minutes int    // increment, e.g. 5, 10 or 15
+	Mode    string // ROUND_EXACT, ROUND_SPAN or ROUND_NEAREST
+	On      string // ROUND_ON_ENTRY, ROUND_ON_REPORT or ROUND_ON_BOTH
+}
+
+// Validate reports unknown modes and invalid increments.
+func (p RoundingPolicy) Validate() error {
+	switch p.Mode {
+	case "", ROUND_EXACT, ROUND_SPAN, ROUND_NEAREST:
+	default:
+		return fmt.Errorf("unknown rounding mode %q, use %s, %s or %s", p.Mode, ROUND_EXACT, ROUND_SPAN, ROUND_NEAREST)
+	}
+	switch p.On {
+	case "", ROUND_ON_ENTRY, ROUND_ON_REPORT, ROUND_ON_BOTH:
+	default:
+		return fmt.Errorf("unknown rounding target %q, use %s, %s or %s", p.On, ROUND_ON_ENTRY, ROUND_ON_REPORT, ROUND_ON_BOTH)
+	}
+	if p.Minutes < 0 || p.Minutes > 60 {
+		return fmt.Errorf("invalid rounding increment of %d minutes", p.Minutes)
+	}
+	return nil
+}
+
+func (p RoundingPolicy) isExact() bool {
+	return p.Minutes <= 0 || p.Mode == "" || p.Mode == ROUND_EXACT
+}
+
+// OnEntry and OnReport report whether the policy applies to the stored times
+// and the billable time respectively. Policies apply to reports by default.
+func (p RoundingPolicy) OnEntry() bool {
+	return p.On == ROUND_ON_ENTRY || p.On == ROUND_ON_BOTH
+}
+
+func (p RoundingPolicy) OnReport() bool {
+	return p.On == "" || p.On == ROUND_ON_REPORT || p.On == ROUND_ON_BOTH
+}
+
+// Round returns entry with its times rounded according to the policy.
+func (p RoundingPolicy) Round(entry RowEntry) RowEntry {
+	if p.isExact() {
+		return entry
+	}
+	increment := Clock(p.Minutes)
+	switch p.Mode {
+	case ROUND_SPAN:
+		start := entry.StartClock() / increment * increment
+		end := (entry.EndClock() + increment - 1) / increment * increment
+		entry.Start, entry.End = start.On(entry.Date), end.On(entry.Date)
+	case ROUND_NEAREST:
+		worked := entry.Duration()
+		entry.End = entry.End.Add(worked.Round(time.Duration(p.Minutes)*time.Minute) - worked)
+	}
+	return entry
+}
+
+// roundingPolicy returns the policy of a project, falling back to the global
+// one.
+func roundingPolicy(projectNr string, config Configuration) RoundingPolicy {
+	if policy, ok := config.ProjectRounding[projectNr]; ok {
+		return policy
+	}
+	return config.Rounding
+}
+
+// roundOnEntry rounds the times of an edited entry before it is stored.
+func roundOnEntry(entry RowEntry, config Configuration) RowEntry {
+	if policy := roundingPolicy(entry.ProjectNr, config); policy.OnEntry() {
+		return policy.Round(entry)
+	}
+	return entry
+}
+
+// billableDuration returns the worked time of entry to bill. A rounding
+// policy with a mode applying to reports takes precedence over the billing
+// increment, which is used otherwise, e.g. for policies only rounding the
+// stored times.
+func billableDuration(entry RowEntry, config Configuration) time.Duration {
+	if policy := roundingPolicy(entry.ProjectNr, config); policy.Mode != "" && policy.OnReport() {
+		return policy.Round(entry).Duration()
+	}
+	return roundBillable(entry.Duration(), config)
+}
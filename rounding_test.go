@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundingPolicy(t *testing.T) {
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	// 08:07 - 16:52 with 30 minutes pause is 8:15
+	entry := RowEntry{Date: date, Start: Clock(8*60 + 7).On(date), End: Clock(16*60 + 52).On(date), Pause: 30 * time.Minute, ProjectNr: "2024-1310"}
+
+	span := RoundingPolicy{Minutes: 15, Mode: ROUND_SPAN}.Round(entry)
+	if span.StartClock() != 8*60 || span.EndClock() != 17*60 {
+		t.Errorf("expected 08:00 - 17:00, got %s - %s", span.StartClock(), span.EndClock())
+	}
+	nearest := RoundingPolicy{Minutes: 10, Mode: ROUND_NEAREST}.Round(entry)
+	if nearest.StartClock() != entry.StartClock() || nearest.Duration() != 8*time.Hour+20*time.Minute {
+		t.Errorf("expected 8:20 starting at 08:07, got %s starting at %s", nearest.Duration(), nearest.StartClock())
+	}
+	if exact := (RoundingPolicy{Minutes: 15, Mode: ROUND_EXACT}).Round(entry); !exact.End.Equal(entry.End) || !exact.Start.Equal(entry.Start) {
+		t.Errorf("expected exact times to be kept, got %s - %s", exact.StartClock(), exact.EndClock())
+	}
+
+	// customers are billed in 15 minutes while the sheet keeps exact minutes
+	config := Configuration{
+		Rounding:        RoundingPolicy{Minutes: 5, Mode: ROUND_SPAN, On: ROUND_ON_BOTH},
+		ProjectRounding: map[string]RoundingPolicy{"2024-1310": {Minutes: 15, Mode: ROUND_SPAN, On: ROUND_ON_REPORT}},
+	}
+	if stored := roundOnEntry(entry, config); !stored.End.Equal(entry.End) || !stored.Start.Equal(entry.Start) {
+		t.Errorf("expected the project to keep exact minutes, got %s - %s", stored.StartClock(), stored.EndClock())
+	}
+	if billable := billableDuration(entry, config); billable != 8*time.Hour+30*time.Minute {
+		t.Errorf("expected 8:30 billable, got %s", billable)
+	}
+	entry.ProjectNr = "2024-1400"
+	if stored := roundOnEntry(entry, config); stored.StartClock() != 8*60+5 || stored.EndClock() != 16*60+55 {
+		t.Errorf("expected 08:05 - 16:55, got %s - %s", stored.StartClock(), stored.EndClock())
+	}
+
+	// rounding only the stored times keeps the billing increment
+	config = Configuration{
+		BillingRoundingMinutes: 60,
+		BillingRoundingMode:    "up",
+		Rounding:               RoundingPolicy{Minutes: 5, Mode: ROUND_SPAN, On: ROUND_ON_ENTRY},
+	}
+	if billable := billableDuration(entry, config); billable != 9*time.Hour {
+		t.Errorf("expected the billing increment to round to 9:00, got %s", billable)
+	}
+
+	if err := (RoundingPolicy{Minutes: 15, Mode: "up"}).Validate(); err == nil {
+		t.Errorf("expected unknown mode to be invalid")
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func entryMatches(re *regexp.Regexp, entry RowEntry) bool {
+	for _, field := range []string{entry.Description, entry.ProjectNr, entry.Project, entry.Customer, entry.Note} {
+		if field != "" && re.MatchString(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// Search returns the positions of all entries whose description, project
+// number, project name, customer or note match the regular expression
+// pattern. Matching is case-insensitive unless the pattern contains upper
+// case letters.
+func (e EntryList) Search(pattern string) ([]entryPosition, error) {
+	if !containsUpper(pattern) {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []entryPosition
+	for _, month := range e.Entries {
+		for _, day := range month {
+			for row, entry := range day {
+				if entryMatches(re, entry) {
+					res = append(res, entryPosition{Date: toSheetDate(entry.Date), Row: row})
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+func containsUpper(s string) bool {
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) entryAt(pos entryPosition) (RowEntry, bool) {
+	day := m.entryList.dayEntries(pos.Date)
+	if day == nil || pos.Row >= len(*day) {
+		return RowEntry{}, false
+	}
+	return (*day)[pos.Row], true
+}
+
+// jumpTo shows the day of pos with its entry selected.
+func (m *Model) jumpTo(pos entryPosition) {
+	m.datepicker.currentDay = time.Date(pos.Date.Year(), pos.Date.Month(), pos.Date.Day(), 0, 0, 0, 0, m.datepicker.currentDay.Location())
+	m.currentSelectedRow = pos.Row
+	m.clampSelectedRow()
+}
+
+func (m *Model) search(pattern string) {
+	results, err := m.entryList.Search(pattern)
+	if err != nil {
+		m.debugMessage = tr("Invalid search:") + " " + err.Error()
+		return
+	}
+	m.searchPattern = pattern
+	m.searchResults = results
+	m.searchIndex = 0
+	m.searchListActive = len(results) > 0
+	m.debugMessage = trf("Found %d entries matching /%s/", len(results), pattern)
+}
+
+// jumpToSearchResult moves offset hits forward or backward from the current
+// hit and shows it.
+func (m *Model) jumpToSearchResult(offset int) {
+	if len(m.searchResults) == 0 {
+		m.debugMessage = tr("No search results!")
+		return
+	}
+	m.searchIndex = helperMod(m.searchIndex+offset, len(m.searchResults))
+	m.jumpTo(m.searchResults[m.searchIndex])
+	m.debugMessage = trf("Search result %d/%d for /%s/", m.searchIndex+1, len(m.searchResults), m.searchPattern)
+}
+
+func (m Model) updateSearchResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Up), key.Matches(msg, keys.ArrowUp):
+		m.searchIndex = helperMod(m.searchIndex-1, len(m.searchResults))
+	case key.Matches(msg, keys.Down), key.Matches(msg, keys.ArrowDown):
+		m.searchIndex = helperMod(m.searchIndex+1, len(m.searchResults))
+	case key.Matches(msg, keys.Edit):
+		m.searchListActive = false
+		m.jumpTo(m.searchResults[m.searchIndex])
+		entry, ok := m.entryAt(m.searchResults[m.searchIndex])
+		if !ok {
+			break
+		}
+		m.editActive = true
+		m.textInputs = m.newEditInputs(entry)
+		m.focusedIndex = 0
+	case key.Matches(msg, keys.CancelEdit):
+		m.searchListActive = false
+	case key.Matches(msg, keys.Quit):
+		return m, m.quit()
+	}
+	return m, nil
+}
+
+func (m Model) viewSearchResultsHeader() string {
+	return m.styles["tableHeader"].Render(" " + trf("Search results for /%s/ (%d/%d)", m.searchPattern, m.searchIndex+1, len(m.searchResults)))
+}
+
+func (m Model) viewSearchResults() string {
+	s := m.viewSearchResultsHeader()
+	s += "\n"
+	first, last := m.searchResultsWindow()
+	for i := first; i < last; i++ {
+		entry, ok := m.entryAt(m.searchResults[i])
+		if !ok {
+			continue
+		}
+		if i == m.searchIndex {
+			s += "  " + m.styles["selectedEntry"].Render(m.viewEntry(entry)) + "\n"
+		} else {
+			s += "  " + m.styles["unselectedEntry"].Render(m.viewEntry(entry)) + "\n"
+		}
+	}
+	if indicator := viewScrollIndicator(first, last, len(m.searchResults)); indicator != "" {
+		s += indicator + "\n"
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearch(t *testing.T) {
+	entries := make([][][]RowEntry, 12)
+	entries[0] = make([][]RowEntry, 31)
+	entries[2] = make([][]RowEntry, 31)
+	date := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	entries[0][5] = []RowEntry{
+		{Date: date, Description: "Code review"},
+		{Date: date, Description: "Meeting", ProjectNr: "2024-1310"},
+	}
+	entries[2][9] = []RowEntry{{Date: date.AddDate(0, 2, 4), Project: "Portal", Note: "review pending"}}
+	entryList := EntryList{Entries: entries}
+
+	results, err := entryList.Search("review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Row != 0 || results[1].Date.Month() != time.March {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	if results, _ := entryList.Search("Review"); len(results) != 0 {
+		t.Errorf("expected search with upper case letters to be case-sensitive, got %+v", results)
+	}
+	if results, _ := entryList.Search(`^2024-\d+$`); len(results) != 1 || results[0].Row != 1 {
+		t.Errorf("expected regex to match project number, got %+v", results)
+	}
+	if _, err := entryList.Search("("); err == nil {
+		t.Error("expected invalid pattern to fail")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// summaryFormula returns the formula totalling a column of the entries of a
+// monthly sheet.
+func summaryFormula(sheet, column string, firstRow, lastRow int) string {
+	return fmt.Sprintf("SUM('%s'!%s%d:%s%d)", sheet, column, firstRow, column, lastRow)
+}
+
+func normalizeFormula(formula string) string {
+	formula = strings.TrimPrefix(strings.TrimSpace(formula), "=")
+	return strings.ToUpper(strings.ReplaceAll(formula, " ", ""))
+}
+
+var summaryFormulaPattern = regexp.MustCompile(`^SUM\('?([^'!]+)'?!([A-Z]+)\d+:([A-Z]+)\d+\)$`)
+
+// isRepairable reports whether the formula of a summary cell may be replaced:
+// it is empty, refers to deleted cells or is the summary formula of the
+// sheet and column with another range. Other formulas were set up by the
+// user and are kept.
+func isRepairable(formula, sheet, column string) bool {
+	formula = normalizeFormula(formula)
+	if formula == "" || strings.Contains(formula, "#REF!") {
+		return true
+	}
+	match := summaryFormulaPattern.FindStringSubmatch(formula)
+	return match != nil && match[1] == normalizeFormula(sheet) && match[2] == column && match[3] == column
+}
+
+// sheetsByMonth arranges the entries keyed by sheet name by month again,
+// see EntryList.Sheets.
+func sheetsByMonth(entries map[string][][]RowEntry) EntryList {
+	res := EntryList{Entries: make([][][]RowEntry, 12)}
+	for _, month := range entries {
+		for _, day := range month {
+			if len(day) > 0 {
+				res.Entries[day[0].Date.Month()-1] = month
+				break
+			}
+		}
+	}
+	return res
+}
+
+// entryRowRange returns the first and last row (1-based) holding a dated
+// entry of a monthly sheet.
+func entryRowRange(f *excelize.File, sheet string, config Configuration) (int, int, error) {
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return 0, 0, err
+	}
+	first, last := 0, 0
+	for i := config.ROW_ID_ENTRY_START; i < len(rows); i++ {
+		if len(rows[i]) > config.COL_ID_DATE && isSerialDate(rows[i][config.COL_ID_DATE]) {
+			if first == 0 {
+				first = i + 1
+			}
+			last = i + 1
+		}
+	}
+	if first == 0 {
+		return 0, 0, fmt.Errorf("no entry rows in sheet %s", sheet)
+	}
+	return first, last, nil
+}
+
+func isSerialDate(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, c := range value {
+		if (c < '0' || c > '9') && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// RepairSummarySheet verifies the formulas of the summary sheet which total
+// the monthly sheets of entries and regenerates the ones not covering all
+// entry rows of their month, see isRepairable. January is in
+// SummaryFirstRow, the other months in the rows below. It returns the number
+// of regenerated formulas.
+func RepairSummarySheet(f *excelize.File, entries map[string][][]RowEntry, config Configuration) (int, error) {
+	if config.SummarySheet == "" {
+		return 0, nil
+	}
+	if index, err := f.GetSheetIndex(config.SummarySheet); err != nil || index < 0 {
+		slog.Warn("Summary sheet does not exist, not updating it", "sheet", config.SummarySheet)
+		return 0, nil
+	}
+
+	columns := make([]string, 0, len(config.SummaryColumns))
+	for column := range config.SummaryColumns {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	sheets := sheetsByMonth(entries)
+	repaired := 0
+	for month := 0; month < 12; month++ {
+		sheet := sheets.sheetNameForMonth(month)
+		if index, err := f.GetSheetIndex(sheet); err != nil || index < 0 {
+			continue
+		}
+		first, last, err := entryRowRange(f, sheet, config)
+		if err != nil {
+			slog.Warn("Not updating summary of sheet", "sheet", sheet, "error", err)
+			continue
+		}
+
+		row := config.SummaryFirstRow + month
+		for _, column := range columns {
+			cell := fmt.Sprintf("%s%d", column, row)
+			formula := summaryFormula(sheet, config.SummaryColumns[column], first, last)
+			current, _ := f.GetCellFormula(config.SummarySheet, cell)
+			if normalizeFormula(current) == normalizeFormula(formula) {
+				continue
+			}
+			if !isRepairable(current, sheet, config.SummaryColumns[column]) {
+				slog.Warn("Keeping custom summary formula", "cell", cell, "formula", current, "expected", formula)
+				continue
+			}
+			if err := f.SetCellFormula(config.SummarySheet, cell, formula); err != nil {
+				return repaired, err
+			}
+			slog.Info("Regenerated summary formula", "cell", cell, "old", current, "new", formula)
+			repaired += 1
+		}
+	}
+	return repaired, nil
+}
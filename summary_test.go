@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestRepairSummarySheet(t *testing.T) {
+	config := Configuration{
+		COL_ID_DATE:        0,
+		ROW_ID_ENTRY_START: 6,
+		SummarySheet:       "Gesamt",
+		SummaryFirstRow:    4,
+		SummaryColumns:     map[string]string{"F": "J", "G": "K"},
+	}
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetName("Sheet1", "01")
+	f.NewSheet("Gesamt")
+	f.SetCellValue("01", "A6", "Datum")
+	for row := 7; row <= 12; row++ {
+		f.SetCellValue("01", fmt.Sprintf("A%d", row), 45658+row)
+	}
+	f.SetCellFormula("Gesamt", "F4", "SUM('01'!J7:J10)")
+	f.SetCellFormula("Gesamt", "G4", "=SUM('01'!K7:K12)")
+
+	repaired, err := RepairSummarySheet(f, nil, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repaired != 1 {
+		t.Errorf("expected one formula to be regenerated, got %d", repaired)
+	}
+	if formula, _ := f.GetCellFormula("Gesamt", "F4"); formula != "SUM('01'!J7:J12)" {
+		t.Errorf("unexpected formula in F4: %q", formula)
+	}
+
+	f.InsertRows("01", 9, 2)
+	f.SetCellValue("01", "A9", 45667)
+	f.SetCellValue("01", "A10", 45667)
+	if repaired, _ := RepairSummarySheet(f, nil, config); repaired != 2 {
+		t.Errorf("expected both formulas to be regenerated after inserting rows, got %d", repaired)
+	}
+	if formula, _ := f.GetCellFormula("Gesamt", "G4"); formula != "SUM('01'!K7:K14)" {
+		t.Errorf("unexpected formula in G4: %q", formula)
+	}
+
+	config.SummarySheet = ""
+	if repaired, _ := RepairSummarySheet(f, nil, config); repaired != 0 {
+		t.Errorf("expected disabled summary sheet to be left alone, got %d", repaired)
+	}
+}
+
+func TestRepairSummarySheetKeepsCustomFormulas(t *testing.T) {
+	config := Configuration{
+		COL_ID_DATE:        0,
+		ROW_ID_ENTRY_START: 6,
+		SummarySheet:       "Gesamt",
+		SummaryFirstRow:    4,
+		SummaryColumns:     map[string]string{"F": "J", "G": "K", "H": "L"},
+	}
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetName("Sheet1", "Januar")
+	f.NewSheet("Gesamt")
+	for row := 8; row <= 12; row++ {
+		f.SetCellValue("Januar", fmt.Sprintf("A%d", row), 45658+row)
+	}
+	f.SetCellFormula("Gesamt", "F4", "SUM('Januar'!J8:J12)*1.19")
+	f.SetCellFormula("Gesamt", "G4", "SUM('Januar'!#REF!)")
+
+	date := time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)
+	entries := map[string][][]RowEntry{"Januar": {{{SheetName: "Januar", Date: date}}}}
+	if repaired, err := RepairSummarySheet(f, entries, config); err != nil || repaired != 2 {
+		t.Errorf("expected the broken and the empty formula to be regenerated, got %d (%v)", repaired, err)
+	}
+	if formula, _ := f.GetCellFormula("Gesamt", "F4"); formula != "SUM('Januar'!J8:J12)*1.19" {
+		t.Errorf("expected the custom formula to be kept, got %q", formula)
+	}
+	if formula, _ := f.GetCellFormula("Gesamt", "G4"); formula != "SUM('Januar'!K8:K12)" {
+		t.Errorf("unexpected formula in G4: %q", formula)
+	}
+	if formula, _ := f.GetCellFormula("Gesamt", "H4"); formula != "SUM('Januar'!L8:L12)" {
+		t.Errorf("unexpected formula in H4: %q", formula)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"time"
+)
+
+// EntryTemplate describes a recurring entry, e.g. a daily standup.
+type EntryTemplate struct {
+	Name        string
+	Start       string // "15:04"
+	End         string // "15:04"
+	Pause       string // Go duration, e.g. "15m"
+	ProjectNr   string
+	Description string
+	// Days the template applies to as workbook weekday abbreviations
+	// ("Mo", "Di", ...). An empty list applies the template to every workday.
+	Days []string
+}
+
+// atClock returns date with the wall clock time given as "15:04".
+func atClock(date time.Time, clock string) (time.Time, error) {
+	c, err := ParseClock(clock)
+	if err != nil {
+		return date, err
+	}
+	return c.On(date), nil
+}
+
+var defaultWeekendDays = []string{"Sa", "So"}
+
+func isWeekend(date time.Time, config Configuration) bool {
+	weekendDays := config.WeekendDays
+	if weekendDays == nil {
+		weekendDays = defaultWeekendDays
+	}
+	return slices.Contains(weekendDays, WEEKDAYS[int(date.Weekday())])
+}
+
+func isHoliday(date time.Time, config Configuration) bool {
+	return slices.Contains(config.Holidays, date.Format(time.DateOnly))
+}
+
+// isDayOff reports whether date is on a weekend or a holiday, work on such
+// days is counted separately.
+func isDayOff(date time.Time, config Configuration) bool {
+	return isWeekend(date, config) || isHoliday(date, config)
+}
+
+// dayOffLabel names why date is a day off or returns "" for workdays.
+func dayOffLabel(date time.Time, config Configuration) string {
+	switch {
+	case isHoliday(date, config):
+		return "holiday"
+	case isWeekend(date, config):
+		return "weekend"
+	}
+	return ""
+}
+
+// IsAbsence reports whether the entry only records vacation or sickness
+// without any working time.
+func (r RowEntry) IsAbsence() bool {
+	return (r.Vacation > 0 || r.Sickness > 0) && r.Start.Equal(r.End)
+}
+
+// workEntries returns the entries recording working time, i.e. without
+// absences.
+func workEntries(entries []RowEntry) []RowEntry {
+	var res []RowEntry
+	for _, entry := range entries {
+		if !entry.IsAbsence() {
+			res = append(res, entry)
+		}
+	}
+	return res
+}
+
+func isVacationDay(day []RowEntry) bool {
+	for _, entry := range day {
+		if entry.Vacation > 0 || entry.Sickness > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t EntryTemplate) appliesTo(date time.Time) bool {
+	if len(t.Days) == 0 {
+		return true
+	}
+	return slices.Contains(t.Days, WEEKDAYS[int(date.Weekday())])
+}
+
+// Entry creates the entry described by the template on the given date.
+func (t EntryTemplate) Entry(date time.Time, sheetName string, projects map[string]Project) (RowEntry, error) {
+	start, err := atClock(date, t.Start)
+	if err != nil {
+		return RowEntry{}, fmt.Errorf("invalid start time in template %q: %w", t.Name, err)
+	}
+	end, err := atClock(date, t.End)
+	if err != nil {
+		return RowEntry{}, fmt.Errorf("invalid end time in template %q: %w", t.Name, err)
+	}
+	var pause time.Duration
+	if t.Pause != "" {
+		if pause, err = time.ParseDuration(t.Pause); err != nil {
+			return RowEntry{}, fmt.Errorf("invalid pause in template %q: %w", t.Name, err)
+		}
+	}
+	return RowEntry{
+		SheetName:   sheetName,
+		Date:        date,
+		Day:         WEEKDAYS[int(date.Weekday())],
+		Start:       start,
+		End:         end,
+		Pause:       pause,
+		ProjectNr:   t.ProjectNr,
+		Project:     projects[t.ProjectNr].Name,
+		Customer:    projects[t.ProjectNr].Customer,
+		Description: t.Description,
+	}, nil
+}
+
+func sameEntry(a, b RowEntry) bool {
+	return a.Start.Equal(b.Start) && a.End.Equal(b.End) && a.ProjectNr == b.ProjectNr && a.Description == b.Description
+}
+
+// addEntriesToDay appends all entries that don't exist yet to day, keeping it
+// sorted by start time. It returns the number of added entries.
+func addEntriesToDay(day *[]RowEntry, entries []RowEntry) int {
+	added := 0
+entryLoop:
+	for _, entry := range entries {
+		for _, existing := range *day {
+			if sameEntry(existing, entry) {
+				continue entryLoop
+			}
+		}
+		*day = append(*day, entry)
+		added += 1
+	}
+	if added > 0 {
+		sort.SliceStable(*day, func(i, j int) bool {
+			return (*day)[i].Start.Before((*day)[j].Start)
+		})
+	}
+	return added
+}
+
+// ApplyTemplates adds the entries of all templates to every day between from
+// and to (both inclusive). Weekends, holidays and vacation days are skipped
+// and entries that already exist are not added again. It returns the number
+// of added entries.
+func ApplyTemplates(e *EntryList, templates []EntryTemplate, from, to time.Time, projects map[string]Project, config Configuration) int {
+	from = toSheetDate(from)
+	to = toSheetDate(to)
+
+	added := 0
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if date.Year() != from.Year() || isDayOff(date, config) {
+			continue
+		}
+		day := e.dayEntries(date)
+		if day == nil {
+			slog.Warn("Cannot apply templates to day of unloaded month", "date", date)
+			continue
+		}
+		if isVacationDay(*day) {
+			continue
+		}
+
+		var newEntries []RowEntry
+		for _, template := range templates {
+			if !template.appliesTo(date) {
+				continue
+			}
+			entry, err := template.Entry(date, e.sheetNameForMonth(int(date.Month())-1), projects)
+			if err != nil {
+				slog.Error("Could not apply template", "template", template.Name, "error", err)
+				continue
+			}
+			newEntries = append(newEntries, entry)
+		}
+		added += addEntriesToDay(day, newEntries)
+	}
+	return added
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestApplyTemplates(t *testing.T) {
+	// 2025-01-06 is a monday
+	day := func(d int) time.Time { return time.Date(2025, time.January, d, 0, 0, 0, 0, time.UTC) }
+
+	// a sheet with a row per day and vacation on the 8th
+	f := excelize.NewFile()
+	defer f.Close()
+	f.NewSheet("01")
+	for d := 1; d <= 31; d++ {
+		row := 6 + d
+		f.SetCellValue("01", fmt.Sprintf("A%d", row), day(d))
+		f.SetCellValue("01", fmt.Sprintf("B%d", row), WEEKDAYS[int(day(d).Weekday())])
+		f.SetCellFloat("01", fmt.Sprintf("J%d", row), 0, -1, 64)
+		if d == 8 {
+			f.SetCellFloat("01", fmt.Sprintf("K%d", row), 8.0/24, -1, 64)
+		}
+	}
+	entries := make([][][]RowEntry, 12)
+	entries[0] = ReturnMonth("01", Configuration{ExcelFile: f, ROW_ID_ENTRY_START: 6})
+	entryList := EntryList{Entries: entries}
+	if vacation := entryList.Entries[0][7]; len(vacation) != 1 || vacation[0].Vacation != 8*time.Hour {
+		t.Fatalf("expected the vacation row to be read, got %+v", vacation)
+	}
+
+	templates := []EntryTemplate{
+		{Name: "Standup", Start: "09:15", End: "09:30", ProjectNr: "2024-1310", Description: "Daily standup"},
+		{Name: "Jour fixe", Start: "14:00", End: "15:00", Description: "Jour fixe", Days: []string{"Di"}},
+	}
+	config := Configuration{Holidays: []string{"2025-01-06"}}
+
+	added := ApplyTemplates(&entryList, templates, day(6), day(12), nil, config)
+	// tuesday: 2, wednesday: vacation, thursday and friday: 1 each
+	if added != 4 {
+		t.Errorf("expected 4 added entries, got %d", added)
+	}
+	if len(entryList.Entries[0][6]) != 2 {
+		t.Errorf("expected standup and jour fixe on tuesday, got %v", entryList.Entries[0][6])
+	}
+	if entryList.Entries[0][6][0].SheetName != "01" || entryList.Entries[0][6][0].Day != "Di" {
+		t.Errorf("unexpected sheet or day of entry: %+v", entryList.Entries[0][6][0])
+	}
+
+	if added := ApplyTemplates(&entryList, templates, day(6), day(12), nil, config); added != 0 {
+		t.Errorf("expected templates not to be duplicated, got %d new entries", added)
+	}
+}
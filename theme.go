@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	tint "github.com/lrstanley/bubbletint"
+)
+
+const DEFAULT_THEME = "afterglow"
+
+// StyleOverride changes individual properties of a style of the editor.
+// Colors are names of the theme's colors like "cyan" or "brightRed", or hex
+// colors like "#ff8800".
+type StyleOverride struct {
+	Foreground string
+	Background string
+	Bold       *bool
+	Italic     *bool
+	Underline  *bool
+	Reverse    *bool
+}
+
+// themeColors are the colors of the current theme which styles can refer to.
+var themeColors = map[string]func() lipgloss.TerminalColor{
+	"fg":           tint.Fg,
+	"bg":           tint.Bg,
+	"selectionBg":  tint.SelectionBg,
+	"cursor":       tint.Cursor,
+	"black":        tint.Black,
+	"red":          tint.Red,
+	"green":        tint.Green,
+	"yellow":       tint.Yellow,
+	"blue":         tint.Blue,
+	"purple":       tint.Purple,
+	"cyan":         tint.Cyan,
+	"white":        tint.White,
+	"brightBlack":  tint.BrightBlack,
+	"brightRed":    tint.BrightRed,
+	"brightGreen":  tint.BrightGreen,
+	"brightYellow": tint.BrightYellow,
+	"brightBlue":   tint.BrightBlue,
+	"brightPurple": tint.BrightPurple,
+	"brightCyan":   tint.BrightCyan,
+	"brightWhite":  tint.BrightWhite,
+}
+
+func themeColor(name string) lipgloss.TerminalColor {
+	if color, ok := themeColors[name]; ok {
+		return color()
+	}
+	return lipgloss.Color(name)
+}
+
+// SetTheme switches to the tint with the given ID from the bubbletint
+// registry.
+func SetTheme(id string) error {
+	if tint.DefaultRegistry == nil {
+		tint.NewDefaultRegistry()
+	}
+	if id == "" {
+		id = DEFAULT_THEME
+	}
+	if !tint.SetTintID(id) {
+		return fmt.Errorf("unknown theme %q, use -theme list to show all themes", id)
+	}
+	return nil
+}
+
+// LoadStyleFile reads style overrides keyed by the style name from a JSON
+// file. The theme has to be set before.
+func LoadStyleFile(path string) (map[string]StyleOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read style file %s: %w", path, err)
+	}
+	var res map[string]StyleOverride
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("could not parse style file %s: %w", path, err)
+	}
+	for name := range res {
+		if _, ok := newStyles(nil)[name]; !ok {
+			return nil, fmt.Errorf("unknown style %q in style file %s", name, path)
+		}
+	}
+	return res, nil
+}
+
+func (o StyleOverride) apply(style lipgloss.Style) lipgloss.Style {
+	if o.Foreground != "" {
+		style = style.Foreground(themeColor(o.Foreground))
+	}
+	if o.Background != "" {
+		style = style.Background(themeColor(o.Background))
+	}
+	if o.Bold != nil {
+		style = style.Bold(*o.Bold)
+	}
+	if o.Italic != nil {
+		style = style.Italic(*o.Italic)
+	}
+	if o.Underline != nil {
+		style = style.Underline(*o.Underline)
+	}
+	if o.Reverse != nil {
+		style = style.Reverse(*o.Reverse)
+	}
+	return style
+}
+
+// newStyles creates the styles of the editor from the current theme and
+// applies the overrides of the user.
+func newStyles(overrides map[string]StyleOverride) map[string]lipgloss.Style {
+	styles := map[string]lipgloss.Style{
+		"header": lipgloss.NewStyle().
+			Bold(true).
+			Border(lipgloss.RoundedBorder(), true, true).
+			Foreground(tint.Cyan()),
+		"tableHeader": lipgloss.NewStyle().
+			Bold(true).
+			Border(lipgloss.RoundedBorder(), false, true, true, true).
+			PaddingBottom(0).
+			MaxHeight(1).
+			Foreground(tint.Cyan()),
+		"unselectedEntry": lipgloss.NewStyle().
+			Inline(true).
+			Foreground(tint.Fg()),
+		"selectedEntry": lipgloss.NewStyle().
+			Inline(true).
+			Bold(true).
+			Foreground(tint.Cyan()),
+		"inputField": lipgloss.NewStyle().
+			Italic(true).
+			Foreground(tint.Cyan()),
+		"inputFieldErr": lipgloss.NewStyle().
+			Italic(true).
+			Foreground(tint.Red()),
+		"visualEntry": lipgloss.NewStyle().
+			Inline(true).
+			Reverse(true).
+			Foreground(tint.Fg()),
+		"dailySum": lipgloss.NewStyle().
+			Bold(true).
+			Foreground(tint.BrightCyan()),
+	}
+	for name, override := range overrides {
+		if style, ok := styles[name]; ok {
+			styles[name] = override.apply(style)
+		}
+	}
+	return styles
+}
+
+func (m *Model) openThemePicker() {
+	m.themePickerActive = true
+	m.themeBefore = tint.ID()
+	m.themeIndex = 0
+	for i, id := range tint.TintIDs() {
+		if id == m.themeBefore {
+			m.themeIndex = i
+		}
+	}
+}
+
+// previewTheme switches to the theme at index of the picker.
+func (m *Model) previewTheme(index int) {
+	ids := tint.TintIDs()
+	m.themeIndex = helperMod(index, len(ids))
+	tint.SetTintID(ids[m.themeIndex])
+	m.styles = newStyles(m.config.Styles)
+}
+
+func (m Model) updateThemePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Up), key.Matches(msg, keys.ArrowUp):
+		m.previewTheme(m.themeIndex - 1)
+	case key.Matches(msg, keys.Down), key.Matches(msg, keys.ArrowDown):
+		m.previewTheme(m.themeIndex + 1)
+	case key.Matches(msg, keys.Edit):
+		m.themePickerActive = false
+		m.debugMessage = trf("Using theme %s, set \"Theme\": %q in the configuration to keep it", tint.DisplayName(), tint.ID())
+	case key.Matches(msg, keys.CancelEdit):
+		m.themePickerActive = false
+		tint.SetTintID(m.themeBefore)
+		m.styles = newStyles(m.config.Styles)
+	case key.Matches(msg, keys.Quit):
+		return m, m.quit()
+	}
+	return m, nil
+}
+
+// THEME_PICKER_VISIBLE is the number of themes listed by the picker.
+const THEME_PICKER_VISIBLE = 15
+
+func (m Model) viewThemePickerHeader() string {
+	return m.styles["tableHeader"].Render(" " + trf("Theme %d/%d: %s", m.themeIndex+1, len(tint.TintIDs()), tint.DisplayName()))
+}
+
+// viewThemePicker lists the themes around the selected one next to a
+// preview of the editor in the selected theme.
+func (m Model) viewThemePicker() string {
+	ids := tint.TintIDs()
+	first, last := m.themePickerWindow()
+
+	var list strings.Builder
+	for i := first; i < last; i++ {
+		if i == m.themeIndex {
+			list.WriteString(m.styles["selectedEntry"].Render("◉ "+ids[i]) + "\n")
+		} else {
+			list.WriteString(m.styles["unselectedEntry"].Render("○ "+ids[i]) + "\n")
+		}
+	}
+
+	date := m.datepicker.currentDay
+	sample := RowEntry{Date: date, Start: Clock(8 * 60).On(date), End: Clock(16*60 + 30).On(date), Project: "Portal", Description: tr("Description")}
+	preview := strings.Join([]string{
+		m.styles["header"].Render(tr("Work Hour Editor")),
+		m.styles["selectedEntry"].Render(sample.View()),
+		m.styles["unselectedEntry"].Render(sample.View()),
+		m.styles["visualEntry"].Render(sample.View()),
+		m.styles["inputField"].Render(sample.StartClock().String()) + " " + m.styles["inputFieldErr"].Render("25:00"),
+		m.styles["dailySum"].Render(trf("Total hours: %s", formatDuration(sample.Duration()))),
+	}, "\n")
+
+	s := m.viewThemePickerHeader() + "\n"
+	s += lipgloss.JoinHorizontal(lipgloss.Top, list.String(), "    ", preview)
+	useTheme := key.NewBinding(key.WithKeys("enter"), key.WithHelp(keys.Edit.Help().Key, tr("Use theme")))
+	s += "\n\n" + viewChoices(useTheme, keys.CancelEdit)
+	return s
+}
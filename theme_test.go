@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	tint "github.com/lrstanley/bubbletint"
+)
+
+func TestStyleOverrides(t *testing.T) {
+	if err := SetTheme("dracula"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTheme(DEFAULT_THEME)
+	if err := SetTheme("no_such_theme"); err == nil {
+		t.Errorf("expected unknown themes to be rejected")
+	}
+
+	path := filepath.Join(t.TempDir(), "styles.json")
+	os.WriteFile(path, []byte(`{"dailySum": {"Foreground": "#ff8800", "Bold": false}, "header": {"Foreground": "yellow"}}`), 0600)
+	overrides, err := LoadStyleFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	styles := newStyles(overrides)
+	if styles["dailySum"].GetForeground() != lipgloss.Color("#ff8800") || styles["dailySum"].GetBold() {
+		t.Errorf("expected the daily sum to be overridden")
+	}
+	if styles["header"].GetForeground() != tint.Yellow() || !styles["header"].GetBold() {
+		t.Errorf("expected the header to use the yellow of the theme and stay bold")
+	}
+	if styles["selectedEntry"].GetForeground() != tint.Cyan() {
+		t.Errorf("expected other styles to use the theme")
+	}
+
+	os.WriteFile(path, []byte(`{"footer": {"Foreground": "red"}}`), 0600)
+	if _, err := LoadStyleFile(path); err == nil {
+		t.Errorf("expected unknown styles to be rejected")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
-	"os"
 	"strings"
 	"time"
 
@@ -14,9 +13,10 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	tint "github.com/lrstanley/bubbletint"
 )
 
+// WEEKDAYS are the weekday abbreviations used in the workbook, independent of
+// the language of the user interface.
 var WEEKDAYS = []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"}
 
 var debugConfig = Configuration{
@@ -40,6 +40,40 @@ func NewEntryList(config Configuration) EntryList {
 	}
 }
 
+// sheetNameForMonth returns the name of the sheet holding the entries of the
+// given month (0-based).
+func (e EntryList) sheetNameForMonth(month int) string {
+	for _, day := range e.Entries[month] {
+		if len(day) > 0 && day[0].SheetName != "" {
+			return day[0].SheetName
+		}
+	}
+	return fmt.Sprintf("%02d", month+1)
+}
+
+// Year returns the year of the loaded entries, or 0 if there are none.
+func (e EntryList) Year() int {
+	for _, month := range e.Entries {
+		for _, day := range month {
+			if len(day) > 0 {
+				return day[0].Date.Year()
+			}
+		}
+	}
+	return 0
+}
+
+// Sheets returns the entries of all loaded months keyed by their sheet name.
+func (e EntryList) Sheets() map[string][][]RowEntry {
+	var sheets = make(map[string][][]RowEntry)
+	for i, month := range e.Entries {
+		if len(month) > 0 {
+			sheets[e.sheetNameForMonth(i)] = month
+		}
+	}
+	return sheets
+}
+
 type DatePicker struct {
 	selected   bool
 	currentDay time.Time
@@ -47,8 +81,8 @@ type DatePicker struct {
 
 func NewDatePicker() DatePicker {
 	var res DatePicker
-	now := time.Now()
-	res.currentDay = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// today's civil date, represented like the dates of the workbook
+	res.currentDay = toSheetDate(time.Now())
 	return res
 }
 
@@ -63,7 +97,6 @@ type Model struct {
 	projectNumbers   map[string]Project
 	projectCustomers map[string]Project
 
-	keys               keyMap
 	help               help.Model
 	currentSelectedRow int
 
@@ -79,6 +112,36 @@ type Model struct {
 	projectNumberVisible      int
 	potentialProjects         []Project
 	lastProjectNumberSearched string
+
+	yanked []RowEntry
+
+	visualActive bool
+	selection    Selection
+
+	promptActive bool
+	promptKind   string
+	prompt       textinput.Model
+
+	searchListActive bool
+	searchPattern    string
+	searchResults    []entryPosition
+	searchIndex      int
+
+	modified          map[time.Time]bool // days whose entries differ from the saved workbook
+	quitConfirmActive bool
+	journal           *Journal
+
+	base          [][][]RowEntry // entries as last read from the workbook on disk
+	fileState     fileState
+	conflicts     []MergeConflict
+	conflictIndex int
+
+	savePreviewActive bool
+	savePreview       string
+
+	themePickerActive bool
+	themeIndex        int
+	themeBefore       string // theme to restore if picking is cancelled
 }
 
 func initialModel(config Configuration) Model {
@@ -94,7 +157,26 @@ func initialModel(config Configuration) Model {
 
 	nr, name, custom := GetProjectNumbers(config)
 
-	return Model{
+	var pending []JournalRecord
+	if config.ReplayJournal {
+		var err error
+		if pending, err = PendingJournal(config.ExcelFileName); err != nil {
+			slog.Error("Could not read journal", "error", err)
+		}
+	}
+	var journal *Journal
+	var err error
+	if !config.ReadOnly {
+		// the journal belongs to whoever holds the lock
+		journal, err = OpenJournal(journalPath(config.ExcelFileName))
+		if err != nil {
+			slog.Error("Could not open journal, changes are not protected against crashes", "error", err)
+		} else if len(pending) == 0 {
+			journal.Clear()
+		}
+	}
+
+	m := Model{
 		datepicker: NewDatePicker(),
 		entryList:  NewEntryList(config),
 		config:     config,
@@ -105,12 +187,11 @@ func initialModel(config Configuration) Model {
 
 		debugMessage: "",
 
-		keys:               keys,
 		help:               help,
 		currentSelectedRow: 0,
 
 		editActive:   false,
-		numColumns:   5,
+		numColumns:   6,
 		textInputs:   []textinput.Model{},
 		focusedIndex: 0,
 
@@ -120,35 +201,28 @@ func initialModel(config Configuration) Model {
 		projectNumberIndex:   0,
 		projectNumberVisible: 10,
 
-		styles: map[string]lipgloss.Style{
-			"header": lipgloss.NewStyle().
-				Bold(true).
-				Border(lipgloss.RoundedBorder(), true, true).
-				Foreground(tint.Cyan()),
-			"tableHeader": lipgloss.NewStyle().
-				Bold(true).
-				Border(lipgloss.RoundedBorder(), false, true, true, true).
-				PaddingBottom(0).
-				MaxHeight(1).
-				Foreground(tint.Cyan()),
-			"unselectedEntry": lipgloss.NewStyle().
-				Inline(true).
-				Foreground(tint.Fg()),
-			"selectedEntry": lipgloss.NewStyle().
-				Inline(true).
-				Bold(true).
-				Foreground(tint.Cyan()),
-			"inputField": lipgloss.NewStyle().
-				Italic(true).
-				Foreground(tint.Cyan()),
-			"inputFieldErr": lipgloss.NewStyle().
-				Italic(true).
-				Foreground(tint.Red()),
-			"dailySum": lipgloss.NewStyle().
-				Bold(true).
-				Foreground(tint.BrightCyan()),
-		},
+		modified: make(map[time.Time]bool),
+		journal:  journal,
+
+		styles: newStyles(config.Styles),
+	}
+
+	// the workbook may be of another year than today, e.g. last year's
+	if year := m.entryList.Year(); year != 0 && year != m.datepicker.currentDay.Year() {
+		day := m.datepicker.currentDay
+		last := time.Date(year, day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		m.datepicker.currentDay = time.Date(year, day.Month(), min(day.Day(), last), 0, 0, 0, 0, time.UTC)
 	}
+
+	m.base = copyEntries(m.entryList.Entries)
+	if m.fileState, err = readFileState(config.ExcelFileName); err != nil {
+		slog.Error("Could not read workbook state, external changes won't be detected", "error", err)
+	}
+
+	for _, date := range m.entryList.ReplayJournal(pending) {
+		m.modified[date] = true
+	}
+	return m
 }
 
 func (m Model) getMonthEntries(i int) *[][]RowEntry {
@@ -169,18 +243,18 @@ func (m Model) getCurrentDayEntries() *[]RowEntry {
 
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
-		m.spinner.Tick,
 		tea.WindowSize(),
+		watchWorkbook(),
 	)
 }
 
 func validateTime(s string) error {
-	_, err := time.Parse("15:04", string(s))
+	_, err := ParseClock(s)
 	return err
 }
 
 func validateDuration(s string) error {
-	_, err := time.ParseDuration(string(s))
+	_, err := parseDuration(s)
 	return err
 }
 
@@ -206,38 +280,74 @@ func trySettingCurrentSelectedProjectNr(m *Model) {
 	}
 }
 
+// yank remembers the given entries for pasting, leaving out absences.
+func (m *Model) yank(entries []RowEntry) {
+	if entries = workEntries(entries); len(entries) == 0 {
+		m.debugMessage = tr("No entries to yank!")
+		return
+	}
+	m.yanked = entries
+	m.debugMessage = trf("Yanked %d entries", len(m.yanked))
+	if m.config.UseSystemClipboard {
+		if err := copyToSystemClipboard(m.yanked); err != nil {
+			slog.Error("Could not copy entries to system clipboard", "error", err)
+			m.debugMessage += " " + tr("(system clipboard failed)")
+		}
+	}
+}
+
+func (m *Model) applyTemplates(from, to time.Time) {
+	if len(m.config.Templates) == 0 {
+		m.debugMessage = tr("No templates configured!")
+		return
+	}
+	added := ApplyTemplates(&m.entryList, m.config.Templates, from, to, m.projectNumbers, m.config)
+	m.debugMessage = trf("Added %d entries from templates (%s - %s)", added, from.Format("02.01."), to.Format("02.01."))
+	if added > 0 {
+		m.markModified(from, to)
+	}
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
 	case tea.KeyMsg:
+		if m.quitConfirmActive {
+			return m.updateQuitConfirm(msg)
+		}
+		if len(m.conflicts) > 0 {
+			return m.updateConflicts(msg)
+		}
+		if m.savePreviewActive {
+			return m.updateSavePreview(msg)
+		}
+		if m.promptActive {
+			return m.updatePrompt(msg)
+		}
+		if m.searchListActive {
+			return m.updateSearchResults(msg)
+		}
+		if m.themePickerActive {
+			return m.updateThemePicker(msg)
+		}
 		switch {
 		case key.Matches(msg, keys.PrevDay) && !m.editActive:
-			if m.datepicker.currentDay.Month() == time.January && m.datepicker.currentDay.Day() == 1 {
-				m.debugMessage = "Reached first day of the year!"
-				break
-			}
-			m.datepicker.currentDay = m.datepicker.currentDay.AddDate(0, 0, -1)
-			if m.currentSelectedRow >= len(*m.getCurrentDayEntries()) {
-				m.currentSelectedRow -= 1
-			}
-			if m.currentSelectedRow < 0 {
-				m.currentSelectedRow = 0
-			}
-			// m.currentSelectedRow = int(math.Min(float64(m.currentSelectedRow), float64(len(*m.getCurrentDayEntries())-1)))
-			// m.entryList.UpdateCurrentIndex(m.datepicker.currentDay)
+			m.changeDay(-1)
 		case key.Matches(msg, keys.NextDay) && !m.editActive:
-			if m.datepicker.currentDay.Month() == time.December && m.datepicker.currentDay.Day() == 31 {
-				m.debugMessage = "Reached last day of the year!"
-				break
-			}
-			m.datepicker.currentDay = m.datepicker.currentDay.AddDate(0, 0, 1)
-			if m.currentSelectedRow >= len(*m.getCurrentDayEntries()) {
-				m.currentSelectedRow -= 1
-			}
-			if m.currentSelectedRow < 0 {
-				m.currentSelectedRow = 0
-			}
-			// m.currentSelectedRow = int(math.Min(float64(m.currentSelectedRow), float64(len(*m.getCurrentDayEntries())-1)))
-			// m.entryList.UpdateCurrentIndex(m.datepicker.currentDay)
+			m.changeDay(1)
+		case key.Matches(msg, keys.PrevWeek) && !m.editActive:
+			m.goToDate(m.datepicker.currentDay.AddDate(0, 0, -7))
+		case key.Matches(msg, keys.NextWeek) && !m.editActive:
+			m.goToDate(m.datepicker.currentDay.AddDate(0, 0, 7))
+		case key.Matches(msg, keys.PrevMonth) && !m.editActive:
+			m.goToDate(addMonths(m.datepicker.currentDay, -1))
+		case key.Matches(msg, keys.NextMonth) && !m.editActive:
+			m.goToDate(addMonths(m.datepicker.currentDay, 1))
+		case key.Matches(msg, keys.Today) && !m.editActive:
+			m.goToDate(toSheetDate(time.Now()))
+		case key.Matches(msg, keys.GoToDate) && !m.editActive:
+			return m, m.openPrompt(PROMPT_GOTO, tr("Go to:")+" ", "08.01. | 2025-01-08 | -3d | last fri | mon | march")
 		case key.Matches(msg, keys.Up) && !m.editActive:
 			m.currentSelectedRow = helperMod(m.currentSelectedRow-1, len(*m.getCurrentDayEntries()))
 			m.debugMessage = fmt.Sprintf("Pressed up (selected=%d/%d)", m.currentSelectedRow, len(*m.getCurrentDayEntries()))
@@ -251,17 +361,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.Save) && !m.editActive:
 			m.debugMessage = "Pressed save"
-			var sheets = make(map[string][][]RowEntry)
-			for _, month := range m.entryList.Entries {
-				if len(month) > 0 {
-					if len(month[0]) > 0 {
-						sheets[month[0][0].SheetName] = month
-					} else if len(month[2]) > 0 {
-						sheets[month[2][0].SheetName] = month
-					}
-				}
+			m.previewSave()
+		case key.Matches(msg, keys.TemplatesDay) && !m.editActive:
+			m.applyTemplates(m.datepicker.currentDay, m.datepicker.currentDay)
+		case key.Matches(msg, keys.TemplatesWeek) && !m.editActive:
+			monday := startOfWeek(m.datepicker.currentDay)
+			m.applyTemplates(monday, monday.AddDate(0, 0, 6))
+		case key.Matches(msg, keys.TemplatesMonth) && !m.editActive:
+			first := m.datepicker.currentDay.AddDate(0, 0, 1-m.datepicker.currentDay.Day())
+			m.applyTemplates(first, first.AddDate(0, 1, -1))
+
+		case key.Matches(msg, keys.YankEntry) && !m.editActive:
+			todaysEntries := *m.getCurrentDayEntries()
+			if len(todaysEntries) == 0 {
+				m.debugMessage = tr("No entry to yank!")
+				break
+			}
+			m.yank(todaysEntries[m.currentSelectedRow : m.currentSelectedRow+1])
+		case key.Matches(msg, keys.YankDay) && !m.editActive:
+			todaysEntries := *m.getCurrentDayEntries()
+			if len(todaysEntries) == 0 {
+				m.debugMessage = tr("No entries to yank!")
+				break
+			}
+			m.yank(todaysEntries)
+		case key.Matches(msg, keys.Paste) && !m.editActive:
+			if len(m.yanked) == 0 {
+				m.debugMessage = tr("Nothing yanked yet!")
+				break
 			}
-			WriteRowEntries(sheets, m.config)
+			pasted := m.entryList.PasteEntries(m.yanked, m.datepicker.currentDay)
+			m.debugMessage = trf("Pasted %d entries", pasted)
+			if pasted > 0 {
+				m.markModified(m.datepicker.currentDay, m.datepicker.currentDay)
+			}
+		case key.Matches(msg, keys.CopyPrevWorkday) && !m.editActive:
+			previous := previousWorkday(m.datepicker.currentDay, m.config)
+			pasted := m.entryList.CopyDays(previous, m.datepicker.currentDay, 1)
+			m.debugMessage = trf("Copied %d entries from %s", pasted, formatDate(previous, "Mon 02.01."))
+			if pasted > 0 {
+				m.markModified(m.datepicker.currentDay, m.datepicker.currentDay)
+			}
+		case key.Matches(msg, keys.CopyLastWeek) && !m.editActive:
+			monday := startOfWeek(m.datepicker.currentDay)
+			pasted := m.entryList.CopyDays(monday.AddDate(0, 0, -7), monday, 7)
+			m.debugMessage = trf("Copied %d entries from last week", pasted)
+			if pasted > 0 {
+				m.markModified(monday, monday.AddDate(0, 0, 6))
+			}
+
 		case key.Matches(msg, keys.FocusPrev):
 			if !m.editActive {
 				break
@@ -277,6 +425,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.focusedIndex = helperMod(m.focusedIndex+1, len(m.textInputs))
 			m.debugMessage = fmt.Sprintf("Focused index: %d", m.focusedIndex)
 
+		case key.Matches(msg, keys.Search) && !m.editActive:
+			return m, m.openPrompt(PROMPT_SEARCH, "/", tr("regular expression"))
+		case key.Matches(msg, keys.NextResult) && !m.editActive:
+			m.jumpToSearchResult(1)
+		case key.Matches(msg, keys.PrevResult) && !m.editActive:
+			m.jumpToSearchResult(-1)
+		case key.Matches(msg, keys.Visual) && !m.editActive:
+			m.visualActive = !m.visualActive
+			m.selection = NewSelection(m.datepicker.currentDay, m.currentSelectedRow)
+		case key.Matches(msg, keys.BulkCommand) && m.visualActive:
+			return m, m.openPrompt(PROMPT_BULK, ":", "project <nr> | replace <old>/<new> | shift <duration> | pause <duration> | delete")
+		case key.Matches(msg, keys.Delete) && m.visualActive:
+			deleted := m.entryList.DeleteSelection(m.selection)
+			m.debugMessage = trf("Deleted %d entries", deleted)
+			m.markModified(m.selection.Anchor.Date, m.selection.Cursor.Date)
+			m.visualActive = false
+			m.clampSelectedRow()
 		case key.Matches(msg, keys.Delete) && !m.editActive && len(m.entryList.Entries) > 0:
 			m.debugMessage = "Trying to delete..."
 			todaysEntries := m.getCurrentDayEntries()
@@ -285,6 +450,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 			*todaysEntries = append((*todaysEntries)[0:m.currentSelectedRow], (*todaysEntries)[m.currentSelectedRow+1:]...)
+			m.markModified(m.datepicker.currentDay, m.datepicker.currentDay)
 			if len(*todaysEntries) == 0 {
 				m.currentSelectedRow = 0
 			} else {
@@ -292,7 +458,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.currentSelectedRow = len(*todaysEntries) - 1
 				}
 			}
-		case key.Matches(msg, keys.Add) && !m.editActive:
+		case key.Matches(msg, keys.Add) && !m.editActive && !m.visualActive:
 			todaysEntries := m.getCurrentDayEntries()
 			var newEntry RowEntry
 			if len(*todaysEntries) > 0 {
@@ -306,7 +472,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			*todaysEntries = append(*todaysEntries, newEntry)
 			m.currentSelectedRow = len(*todaysEntries) - 1
 			fallthrough // automatically edit new entry
-		case key.Matches(msg, keys.Edit):
+		case key.Matches(msg, keys.Edit) && !m.visualActive:
 			m.debugMessage = "Pressed edit..."
 			todaysEntries := *m.getCurrentDayEntries()
 			if len(todaysEntries) == 0 {
@@ -316,63 +482,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.editActive = !m.editActive
 			entry := (todaysEntries)[m.currentSelectedRow]
 			if m.editActive {
-				// create inputs for all columns except date
-				inputs := make([]textinput.Model, m.numColumns)
-				for i := range inputs {
-					t := textinput.New()
-					switch i {
-					case 0:
-						// Start time
-						t.Placeholder = entry.Start.Format("15:04")
-						t.CharLimit = 5
-						t.Width = 5
-						t.Validate = validateTime
-					case 1:
-						// End time
-						t.Placeholder = entry.End.Format("15:04")
-						t.CharLimit = 5
-						t.Width = 5
-						t.Validate = validateTime
-					case 2:
-						// Pause
-						t.Placeholder = entry.Pause.String()
-						t.CharLimit = 9
-						t.Width = 9
-						t.Validate = validateDuration
-					case 3:
-						t.Placeholder = "Description"
-						t.SetValue(entry.Description)
-						// if t.Placeholder = entry.Description; t.Placeholder == "" {
-						// }
-						t.Width = 40
-					case 4:
-						if t.Placeholder = entry.ProjectNr; t.Placeholder == "" {
-							t.Placeholder = "Project-Nr."
-						}
-						t.CharLimit = 9
-						t.Width = 9
-					default:
-						t.Placeholder = "UNDEFINED FIELD"
-					}
-
-					inputs[i] = t
-				}
-				m.textInputs = inputs
+				m.textInputs = m.newEditInputs(entry)
 				m.focusedIndex = 0
 			} else {
-				m.debugMessage = "Saved entry starting at " + m.textInputs[0].Value()
-				entry.Date = m.datepicker.currentDay
-				entry.Day = WEEKDAYS[int(entry.Date.Weekday())]
-				entry.Start, _ = time.Parse("15:04", readTextInputWithDefault(&m.textInputs[0]))
-				entry.End, _ = time.Parse("15:04", readTextInputWithDefault(&m.textInputs[1]))
-				entry.Pause, _ = time.ParseDuration(readTextInputWithDefault(&m.textInputs[2]))
-				entry.Description = readTextInputWithDefault(&m.textInputs[3])
+				m.debugMessage = tr("Saved entry starting at") + " " + m.textInputs[0].Value()
 				trySettingCurrentSelectedProjectNr(&m)
-				entry.ProjectNr = readTextInputWithDefault(&m.textInputs[4])
+				entry = roundOnEntry(m.entryFromInputs(entry), m.config)
 				entry.Project = m.projectNumbers[entry.ProjectNr].Name
+				entry.Customer = m.projectNumbers[entry.ProjectNr].Customer
 				slog.Info("Trying to set project information...", "entry", entry)
 			}
 			m.entryList.Entries[m.datepicker.currentDay.Month()-1][m.datepicker.currentDay.Day()-1][m.currentSelectedRow] = entry
+			if !m.editActive {
+				// journal the day once the edited entry is stored
+				m.markModified(m.datepicker.currentDay, m.datepicker.currentDay)
+			}
 
 		case key.Matches(msg, keys.ArrowUp) && m.editActive && m.focusedIndex == 4:
 			m.projectNumberIndex = helperMod(m.projectNumberIndex-1, len(m.potentialProjects))
@@ -385,12 +509,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.editActive = false
 				m.textInputs = []textinput.Model{}
 			}
-
-		case key.Matches(msg, keys.Help):
+			m.visualActive = false
+
+		case key.Matches(msg, keys.DurationMode) && !m.editActive:
+			SetDurationMode(nextDurationMode())
+			m.debugMessage = trf("Showing durations as %s", tr(durationModeLabels[durationMode]))
+		case key.Matches(msg, keys.ThemePicker) && !m.editActive:
+			m.openThemePicker()
+		case key.Matches(msg, keys.Help) && !(m.editActive && isTyped(msg)):
 			m.help.ShowAll = !m.help.ShowAll
-		case key.Matches(msg, keys.Quit):
-			return m, tea.Quit
+		case key.Matches(msg, keys.Quit) && !(m.editActive && isTyped(msg)):
+			return m, m.quit()
+		}
+
+	case workbookCheckMsg:
+		if !m.editActive {
+			m.checkWorkbook()
 		}
+		return m, watchWorkbook()
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -398,7 +534,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.debugMessage = fmt.Sprintf("Resized to %dx%d", m.width, m.height)
 
 	default:
-		if m.focusedIndex == 4 {
+		if m.promptActive {
+			var cmd tea.Cmd
+			m.prompt, cmd = m.prompt.Update(msg)
+			return m, cmd
+		}
+		if m.editActive && m.focusedIndex == 4 {
 
 			if m.lastProjectNumberSearched != m.textInputs[4].Value() {
 				m.lastProjectNumberSearched = m.textInputs[4].Value()
@@ -425,6 +566,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.visualActive {
+		m.selection.Cursor = entryPosition{Date: toSheetDate(m.datepicker.currentDay), Row: m.currentSelectedRow}
+	}
+
 	if m.editActive {
 		return m, m.updateInputs(msg)
 	}
@@ -432,6 +577,137 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// changeDay moves the current day by offset days, staying within the year of
+// the workbook.
+func (m *Model) changeDay(offset int) {
+	day := m.datepicker.currentDay.AddDate(0, 0, offset)
+	if day.Year() < m.datepicker.currentDay.Year() {
+		m.debugMessage = tr("Reached first day of the year!")
+		return
+	}
+	if day.Year() > m.datepicker.currentDay.Year() {
+		m.debugMessage = tr("Reached last day of the year!")
+		return
+	}
+	m.datepicker.currentDay = day
+	m.clampSelectedRow()
+}
+
+// clampSelectedRow keeps the selected row within the entries of the current
+// day after entries were removed.
+func (m *Model) clampSelectedRow() {
+	if m.currentSelectedRow >= len(*m.getCurrentDayEntries()) {
+		m.currentSelectedRow = len(*m.getCurrentDayEntries()) - 1
+	}
+	if m.currentSelectedRow < 0 {
+		m.currentSelectedRow = 0
+	}
+}
+
+// entryStyle returns the style of an entry of the current day which is not
+// under the cursor.
+func (m Model) entryStyle(row int) lipgloss.Style {
+	if m.visualActive && m.selection.Contains(m.datepicker.currentDay, row) {
+		return m.styles["visualEntry"]
+	}
+	return m.styles["unselectedEntry"]
+}
+
+// newEditInputs creates the inputs for all columns except the date of entry.
+func (m Model) newEditInputs(entry RowEntry) []textinput.Model {
+	inputs := make([]textinput.Model, m.numColumns)
+	for i := range inputs {
+		t := textinput.New()
+		switch i {
+		case 0:
+			// Start time
+			t.Placeholder = entry.StartClock().String()
+			t.CharLimit = 5
+			t.Width = 5
+			t.Validate = validateTime
+		case 1:
+			// End time
+			if entry.HasEnd() {
+				t.Placeholder = (entry.EndClock() % MINUTES_PER_DAY).String()
+			}
+			t.CharLimit = 5
+			t.Width = 5
+			t.Validate = validateTime
+		case 2:
+			// Pause
+			t.Placeholder = formatDuration(entry.Pause)
+			t.CharLimit = 9
+			t.Width = 9
+			t.Validate = validateDuration
+		case 3:
+			t.Placeholder = tr("Description")
+			t.SetValue(entry.Description)
+			// if t.Placeholder = entry.Description; t.Placeholder == "" {
+			// }
+			t.Width = 40
+		case 4:
+			if t.Placeholder = entry.ProjectNr; t.Placeholder == "" {
+				t.Placeholder = tr("Project-Nr.")
+			}
+			t.CharLimit = 9
+			t.Width = 9
+		case 5:
+			// Break intervals
+			t.Placeholder = "12:00-12:30, ..."
+			t.SetValue(formatBreaks(entry.Breaks))
+			t.Width = 24
+			t.Validate = validateBreaks
+		default:
+			t.Placeholder = "UNDEFINED FIELD"
+		}
+
+		inputs[i] = t
+	}
+	return inputs
+}
+
+// entryFromInputs returns entry with the values of the edit inputs.
+func (m Model) entryFromInputs(entry RowEntry) RowEntry {
+	entry.Date = m.datepicker.currentDay
+	entry.Day = WEEKDAYS[int(entry.Date.Weekday())]
+	start, _ := ParseClock(readTextInputWithDefault(&m.textInputs[0]))
+	end, err := ParseClock(readTextInputWithDefault(&m.textInputs[1]))
+	if end < start && err == nil {
+		// ends on the following day, an empty end stays missing
+		end += MINUTES_PER_DAY
+	}
+	entry.Start, entry.End = start.On(entry.Date), end.On(entry.Date)
+	entry.Pause, _ = parseDuration(readTextInputWithDefault(&m.textInputs[2]))
+	// the placeholders of the description and a missing project are no values
+	entry.Description = m.textInputs[3].Value()
+	if projectNr := m.textInputs[4].Value(); projectNr != "" {
+		entry.ProjectNr = projectNr
+	}
+	breaks, _ := ParseBreaks(m.textInputs[5].Value())
+	if len(breaks) > 0 || len(entry.Breaks) > 0 {
+		entry.SetBreaks(breaks)
+	}
+	return entry
+}
+
+// viewEditPreview shows the times the edited entry is stored with if its
+// rounding policy changes them, or the pause its break intervals add up to.
+func (m Model) viewEditPreview() string {
+	todaysEntries := *m.getCurrentDayEntries()
+	if m.currentSelectedRow >= len(todaysEntries) {
+		return ""
+	}
+	entry := m.entryFromInputs(todaysEntries[m.currentSelectedRow])
+	rounded := roundOnEntry(entry, m.config)
+	if rounded.Start.Equal(entry.Start) && rounded.End.Equal(entry.End) {
+		if len(entry.Breaks) > 0 {
+			return tr("Pause") + ": " + formatDuration(entry.Pause)
+		}
+		return ""
+	}
+	return trf("Stored as %s - %s (%s)", rounded.StartClock(), rounded.describeEnd(), formatDuration(rounded.Duration()))
+}
+
 func (m *Model) updateInputs(msg tea.Msg) tea.Cmd {
 	cmds := make([]tea.Cmd, len(m.textInputs))
 
@@ -445,14 +721,33 @@ func (m *Model) updateInputs(msg tea.Msg) tea.Cmd {
 }
 
 func (r RowEntry) View() string {
-	return fmt.Sprintf("%10s  %8s → %-8s [%9s] %20.20s :  %20.20s",
-		r.Date.Format("Mon 02.01."),
-		r.Start.Format("15:04"),
-		r.End.Format("15:04"),
-		r.Pause.String(),
-		r.Project,
-		r.Description,
-	)
+	return defaultTableLayout.render(r.columns(), false)
+}
+
+// previousDayEntries returns the entries of the day before the current one.
+func (m Model) previousDayEntries() []RowEntry {
+	day := m.datepicker.currentDay
+	if yesterday := day.AddDate(0, 0, -1); yesterday.Year() == day.Year() {
+		if entries := m.entryList.dayEntries(yesterday); entries != nil {
+			return *entries
+		}
+	}
+	return nil
+}
+
+// currentDayTotal returns the time worked on the current day, including the
+// part after midnight of overnight entries of the day before.
+func (m Model) currentDayTotal() time.Duration {
+	return dayTotal(m.previousDayEntries(), *m.getCurrentDayEntries(), m.datepicker.currentDay)
+}
+
+// viewEntry renders entry, flagging work on weekends and holidays.
+func (m Model) viewEntry(entry RowEntry) string {
+	s := m.tableLayout().render(entry.columns(), false)
+	if label := dayOffLabel(entry.Date, m.config); label != "" {
+		return s + " (" + tr(label) + ")"
+	}
+	return s
 }
 
 func (m *Model) ViewAsEdit() string {
@@ -472,98 +767,202 @@ func (m *Model) ViewAsEdit() string {
 		s += res
 	}
 
+	if preview := m.viewEditPreview(); preview != "" {
+		s += "\n" + preview
+	}
 	return m.styles["inputField"].Render(s)
 }
 
-func (m Model) View() string {
+// viewTop renders the title, the current date and the calendar of its month.
+func (m Model) viewTop() string {
 	s := ""
-	s += m.styles["header"].Render("Work Hour Editor")
+	title := tr("Work Hour Editor")
+	if len(m.modified) > 0 {
+		title += " [+]"
+	}
+	if m.config.ReadOnly {
+		title += " [" + tr("read-only") + "]"
+	}
+	s += m.styles["header"].Render(title)
 	s += "\n"
-	s += fmt.Sprintf("Current Date: [%12s] \n", m.datepicker.currentDay.Format("Mon 02.01.06"))
+	s += m.viewCurrentDate() + "\n"
+	s += fmt.Sprintf("\n")
+	s += m.viewCalendar()
 	s += fmt.Sprintf("\n")
+	return s
+}
 
-	s += m.styles["tableHeader"].Render(
-		fmt.Sprintf(" %-10s  %-8s   %-8s %-9s   %-20s    %-20s", "Date", "Start", "End", "Pause", "Project", "Description"),
-	)
-	s += "\n"
+// viewCurrentDate renders the current date between arrows switching to the
+// previous and next day when clicked.
+func (m Model) viewCurrentDate() string {
+	return fmt.Sprintf("%s: %s [%12s] %s ", tr("Current Date"), DATE_ARROW_PREV, formatDate(m.datepicker.currentDay, "Mon 02.01.06"), DATE_ARROW_NEXT)
+}
 
+func (m Model) viewTableHeader() string {
+	return m.styles["tableHeader"].Render(" " + m.tableLayout().render(tableColumns{
+		Date:        tr("Date"),
+		Start:       tr("Start"),
+		End:         tr("End"),
+		Pause:       tr("Pause"),
+		Project:     tr("Project"),
+		Description: tr("Description"),
+	}, true))
+}
+
+// tableLine is a line of the entry table and what clicking it refers to.
+type tableLine struct {
+	text  string
+	kind  int // one of the LINE_ constants
+	index int // of the entry or project
+}
+
+const (
+	LINE_ENTRY = iota
+	LINE_INPUTS
+	LINE_PROJECT
+	LINE_OTHER
+)
+
+// viewEntryTable renders the entries of the current day, the inputs of the
+// edited entry and the projects matching its project number.
+func (m Model) viewEntryTable() []tableLine {
+	var res []tableLine
 	indent := "  "
 	todaysEntries := *m.getCurrentDayEntries()
-	var totalWorkDay time.Duration = time.Duration(0)
-	if len(todaysEntries) <= 0 {
-
-	} else {
-		for i := 0; i < m.currentSelectedRow; i++ {
-			s += indent
-			s += m.styles["unselectedEntry"].Render(todaysEntries[i].View()) + "\n"
-			totalWorkDay += todaysEntries[i].End.Sub(todaysEntries[i].Start)
-			totalWorkDay -= todaysEntries[i].Pause
+	for i, entry := range todaysEntries {
+		if i != m.currentSelectedRow {
+			res = append(res, tableLine{indent + m.entryStyle(i).Render(m.viewEntry(entry)), LINE_ENTRY, i})
+			continue
+		}
+		if !m.editActive {
+			res = append(res, tableLine{indent + m.styles["selectedEntry"].Render(m.viewEntry(entry)), LINE_ENTRY, i})
+			continue
 		}
-		if m.editActive {
-			s += indent + strings.Repeat(" ", 12)
-			inputRow := m.ViewAsEdit()
-			s += inputRow + "\n"
 
-			if m.focusedIndex == 4 && m.textInputs[4].Value() != "" {
+		for _, line := range strings.Split(strings.Repeat(" ", m.editIndent())+m.ViewAsEdit(), "\n") {
+			res = append(res, tableLine{line, LINE_INPUTS, i})
+		}
+		if m.focusedIndex == 4 && m.textInputs[4].Value() != "" {
+			indent := strings.Repeat(" ", 10)
+			res = append(res, tableLine{"", LINE_OTHER, 0})
+			for j, project := range m.potentialProjects {
+				if j == m.projectNumberIndex {
+					res = append(res, tableLine{m.styles["selectedEntry"].Render(fmt.Sprintf("%s%s %s: %-50.50s [%20.20s]", indent, "◉", project.ID, project.Name, project.Customer)), LINE_PROJECT, j})
+				} else {
+					res = append(res, tableLine{m.styles["selectedEntry"].Render(fmt.Sprintf("%s%s %s: %50.50s [%20.20s]", indent, "○", project.ID, project.Name, project.Customer)), LINE_PROJECT, j})
+				}
+			}
+		}
+	}
+	return res
+}
 
-				indent := strings.Repeat(" ", 10)
-				s += "\n"
+func (m Model) View() string {
+	s := m.viewTop()
 
-				for i := 0; i < len(m.potentialProjects); i++ {
-					if i == m.projectNumberIndex {
-						s += m.styles["selectedEntry"].Render(fmt.Sprintf("%s%s %s: %-50.50s [%20.20s]", indent, "◉", m.potentialProjects[i].ID, m.potentialProjects[i].Name, m.potentialProjects[i].Customer)) + "\n"
-					} else {
-						s += m.styles["selectedEntry"].Render(fmt.Sprintf("%s%s %s: %50.50s [%20.20s]", indent, "○", m.potentialProjects[i].ID, m.potentialProjects[i].Name, m.potentialProjects[i].Customer)) + "\n"
-					}
-				}
+	if len(m.conflicts) > 0 {
+		s += m.viewConflicts()
+		if m.quitConfirmActive {
+			s += "\n" + m.viewQuitConfirm()
+		}
+		return s
+	}
 
-			}
+	if m.savePreviewActive {
+		s += m.viewSavePreview()
+		return s
+	}
 
-		} else {
-			if m.currentSelectedRow >= len(todaysEntries) {
-				m.debugMessage = "Current row > entries length"
-			} else {
-				s += indent
-				s += m.styles["selectedEntry"].Render(todaysEntries[m.currentSelectedRow].View()) + "\n"
-				totalWorkDay += todaysEntries[m.currentSelectedRow].End.Sub(todaysEntries[m.currentSelectedRow].Start)
-				totalWorkDay -= todaysEntries[m.currentSelectedRow].Pause
-			}
+	if m.themePickerActive {
+		s += m.viewThemePicker()
+		if m.quitConfirmActive {
+			s += "\n" + m.viewQuitConfirm()
 		}
-		for i := m.currentSelectedRow + 1; i < len(todaysEntries); i++ {
-			s += indent
-			s += m.styles["unselectedEntry"].Render(todaysEntries[i].View()) + "\n"
-			totalWorkDay += todaysEntries[i].End.Sub(todaysEntries[i].Start)
-			totalWorkDay -= todaysEntries[i].Pause
+		return s
+	}
+
+	if m.searchListActive {
+		s += m.viewSearchResults()
+		if m.quitConfirmActive {
+			s += "\n" + m.viewQuitConfirm()
 		}
+		s += m.viewFooter()
+		return s
 	}
 
+	s += m.viewTableHeader()
 	s += "\n"
 
-	totalWorkDay = totalWorkDay.Round(time.Duration(1) * time.Minute)
+	lines, indicator := m.visibleEntryTable()
+	for _, line := range lines {
+		s += line.text + "\n"
+	}
+	if indicator != "" {
+		s += indicator + "\n"
+	}
+	s += m.viewDaySummary()
+	s += m.viewFooter()
+	return s
+}
 
-	s += m.styles["dailySum"].Render(fmt.Sprintf("Total hours: %02.0f:%02d", totalWorkDay.Hours(), int(totalWorkDay.Minutes())%60))
+// viewDaySummary shows the details of the selected entry, the totals and
+// the violations of the working time act below the entry table.
+func (m Model) viewDaySummary() string {
+	s := ""
+	todaysEntries := *m.getCurrentDayEntries()
+	if details := m.viewDetails(); details != "" {
+		s += "\n" + details + "\n"
+	}
 
-	s += "\n\n"
-	s += "\n\n#######\nDebug: " + m.debugMessage + "\n#######\n\n"
+	s += "\n"
+
+	totalWorkDay := m.currentDayTotal().Round(time.Duration(1) * time.Minute)
+
+	s += m.styles["dailySum"].Render(trf("Total hours: %s", formatDuration(totalWorkDay)))
+	if len(todaysEntries) > 0 {
+		if label := dayOffLabel(todaysEntries[0].Date, m.config); label != "" {
+			s += m.styles["dailySum"].Render(" " + trf("worked on a %s", tr(label)))
+		}
+	}
+
+	for _, violation := range CheckWorkingTimeAct(m.previousDayEntries(), todaysEntries, m.datepicker.currentDay) {
+		s += "\n" + m.styles["inputFieldErr"].Render("ArbZG: "+violation.Message)
+	}
+
+	workTime := SumWorkTime(m.entryList.Entries[m.datepicker.currentDay.Month()-1], m.config)
+	s += "\n" + m.styles["dailySum"].Render(trf("Month: %s, thereof %s on weekends and holidays",
+		formatDuration(workTime.Total()), formatDuration(workTime.DaysOff)))
 
-	s += m.help.View(m.keys)
+	s += "\n\n"
+	if m.visualActive {
+		s += "\n" + trf("-- VISUAL -- %d entries selected", len(m.entryList.SelectedEntries(m.selection)))
+	}
+	if m.promptActive {
+		s += "\n" + m.prompt.View()
+	}
+	if m.quitConfirmActive {
+		s += "\n" + m.viewQuitConfirm()
+	}
 	return s
 }
 
-func Start(config Configuration) {
-	// Set up color scheme
-	tint.NewDefaultRegistry()
-	tint.SetTint(tint.TintAfterglow)
+// viewFooter shows the debug message and the help at the bottom.
+func (m Model) viewFooter() string {
+	return "\n\n#######\nDebug: " + m.debugMessage + "\n#######\n\n" + m.help.View(keys)
+}
 
+func Start(config Configuration) error {
 	var resultChan = make(chan tea.Model)
 	l := NewLoadingScreen(resultChan)
 	go func() {
 		resultChan <- initialModel(config)
 	}()
 
-	p := tea.NewProgram(l, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Println("An error occurred: ", err)
-		os.Exit(1)
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if !config.DisableMouse {
+		options = append(options, tea.WithMouseCellMotion())
 	}
+	p := tea.NewProgram(l, options...)
+	_, err := p.Run()
+	return err
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	tint "github.com/lrstanley/bubbletint"
+)
+
+// VIEWPORT_MIN_LINES is the least number of lines a scrolled list shows,
+// even if the terminal is too small to fit them.
+const VIEWPORT_MIN_LINES = 3
+
+// scrollWindow returns the range [first, last) of count lines to show in
+// height lines, keeping the selected line in the middle where possible. A
+// height of 0 or less shows all lines.
+func scrollWindow(count, selected, height int) (int, int) {
+	if height <= 0 || count <= height {
+		return 0, count
+	}
+	first := max(0, min(selected-height/2, count-height))
+	return first, first + height
+}
+
+// screenHeight returns the number of lines s takes on the screen, counting
+// lines wider than the terminal as wrapped.
+func (m Model) screenHeight(s string) int {
+	if m.width <= 0 {
+		return lipgloss.Height(s)
+	}
+	height := 0
+	for _, line := range strings.Split(s, "\n") {
+		height += max(1, (lipgloss.Width(line)+m.width-1)/m.width)
+	}
+	return height
+}
+
+// viewportHeight returns the number of lines left for a list of count lines
+// if the rest of the screen consists of the given blocks, which are joined
+// where the list goes in between. The list is cut by one line more for the
+// position indicator. 0 means the whole list fits.
+func (m Model) viewportHeight(count int, rest ...string) int {
+	if m.height <= 0 {
+		return 0
+	}
+	free := m.height - m.screenHeight(strings.Join(rest, ""))
+	if count <= free {
+		return 0
+	}
+	return max(free-1, VIEWPORT_MIN_LINES)
+}
+
+// viewScrollIndicator shows which lines of a list are visible and whether
+// there are more above or below them. It is empty if the list isn't cut.
+func viewScrollIndicator(first, last, count int) string {
+	if first == 0 && last == count {
+		return ""
+	}
+	up, down := " ", " "
+	if first > 0 {
+		up = "↑"
+	}
+	if last < count {
+		down = "↓"
+	}
+	return lipgloss.NewStyle().Foreground(tint.BrightBlack()).
+		Render("  " + up + " " + trf("%d-%d of %d", first+1, last, count) + " " + down)
+}
+
+// selectedTableLine returns the line of the entry table which has to stay
+// visible: the selected project while choosing one, the inputs while
+// editing and the selected entry otherwise.
+func (m Model) selectedTableLine(table []tableLine) int {
+	for i, line := range table {
+		switch {
+		case m.editActive && line.kind == LINE_PROJECT && line.index == m.projectNumberIndex:
+			return i
+		case m.editActive && line.kind == LINE_INPUTS && (m.focusedIndex != 4 || len(m.potentialProjects) == 0):
+			return i
+		case !m.editActive && line.kind == LINE_ENTRY && line.index == m.currentSelectedRow:
+			return i
+		}
+	}
+	return 0
+}
+
+// visibleEntryTable returns the lines of the entry table fitting the
+// terminal and the position indicator if they don't all fit.
+func (m Model) visibleEntryTable() ([]tableLine, string) {
+	table := m.viewEntryTable()
+	height := m.viewportHeight(len(table), m.viewTop(), m.viewTableHeader()+"\n", m.viewDaySummary(), m.viewFooter())
+	first, last := scrollWindow(len(table), m.selectedTableLine(table), height)
+	return table[first:last], viewScrollIndicator(first, last, len(table))
+}
+
+// searchResultsWindow returns the range of the search results fitting the
+// terminal.
+func (m Model) searchResultsWindow() (int, int) {
+	rest := []string{m.viewTop(), m.viewSearchResultsHeader() + "\n"}
+	if m.quitConfirmActive {
+		rest = append(rest, "\n"+m.viewQuitConfirm())
+	}
+	rest = append(rest, m.viewFooter())
+	return scrollWindow(len(m.searchResults), m.searchIndex, m.viewportHeight(len(m.searchResults), rest...))
+}
+
+// themePickerWindow returns the range of the themes listed by the picker,
+// at most THEME_PICKER_VISIBLE.
+func (m Model) themePickerWindow() (int, int) {
+	count := len(tint.TintIDs())
+	height := THEME_PICKER_VISIBLE
+	// the choices follow the list, see viewThemePicker
+	if fit := m.viewportHeight(count, m.viewTop(), m.viewThemePickerHeader()+"\n", "\n\n"+viewChoices(keys.Edit, keys.CancelEdit)); fit > 0 {
+		height = min(height, fit)
+	}
+	return scrollWindow(count, m.themeIndex, height)
+}
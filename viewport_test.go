@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestScrollWindow(t *testing.T) {
+	if err := SetTheme(DEFAULT_THEME); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct{ count, selected, height, first, last int }{
+		{5, 4, 0, 0, 5},
+		{5, 4, 10, 0, 5},
+		{40, 0, 10, 0, 10},
+		{40, 20, 10, 15, 25},
+		{40, 39, 10, 30, 40},
+	} {
+		if first, last := scrollWindow(c.count, c.selected, c.height); first != c.first || last != c.last {
+			t.Errorf("scrollWindow(%d, %d, %d) = %d, %d, expected %d, %d", c.count, c.selected, c.height, first, last, c.first, c.last)
+		}
+	}
+	if s := viewScrollIndicator(0, 10, 40); !strings.Contains(s, "1-10 of 40") || strings.Contains(s, "↑") || !strings.Contains(s, "↓") {
+		t.Errorf("unexpected indicator %q", s)
+	}
+	if s := viewScrollIndicator(0, 5, 5); s != "" {
+		t.Errorf("expected no indicator for a list which fits, got %q", s)
+	}
+}
+
+func TestScrollEntryTable(t *testing.T) {
+	if err := SetTheme(DEFAULT_THEME); err != nil {
+		t.Fatal(err)
+	}
+	date := time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)
+	entryList := newBulkTestEntries()
+	entryList.Entries[0][6] = nil
+	for i := 0; i < 40; i++ {
+		start := Clock(6*60 + i*15)
+		entryList.Entries[0][6] = append(entryList.Entries[0][6], RowEntry{
+			Date: date, Start: start.On(date), End: (start + 15).On(date), Description: "Ticket",
+		})
+	}
+	var m tea.Model = Model{
+		datepicker:         DatePicker{currentDay: date},
+		entryList:          entryList,
+		styles:             newStyles(nil),
+		modified:           make(map[time.Time]bool),
+		numColumns:         6,
+		width:              120,
+		height:             40,
+		currentSelectedRow: 30,
+	}
+	model := func() Model { return m.(Model) }
+
+	view := model().View()
+	if h := lipgloss.Height(view); h > 40 {
+		t.Errorf("expected the view to fit 40 lines, got %d", h)
+	}
+	if !strings.Contains(view, "of 40") {
+		t.Errorf("expected a position indicator in\n%s", view)
+	}
+	lines, _ := model().visibleEntryTable()
+	if len(lines) >= 40 {
+		t.Fatalf("expected the table to be cut, got %d lines", len(lines))
+	}
+	selected := -1
+	for i, line := range lines {
+		if line.kind == LINE_ENTRY && line.index == 30 {
+			selected = i
+		}
+	}
+	if selected < 0 {
+		t.Fatal("expected the selected entry to be visible")
+	}
+
+	// the full help and a debug message wrapping in the terminal still fit
+	full := model()
+	full.help.ShowAll = true
+	full.debugMessage = strings.Repeat("long message ", 20)
+	full.height = 60
+	view = full.View()
+	if h := lipgloss.Height(lipgloss.NewStyle().Width(full.width).Render(view)); h > 60 || !strings.Contains(view, "of 40") {
+		t.Errorf("expected the view with the full help to fit 60 lines cutting the table, got %d lines", h)
+	}
+
+	// clicks refer to the visible lines
+	top := strings.Count(model().viewTop(), "\n") + lipgloss.Height(model().viewTableHeader())
+	m, _ = m.Update(tea.MouseMsg{X: 10, Y: top + selected + 1, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if row := model().currentSelectedRow; row != 31 {
+		t.Errorf("expected a click below the selected entry to select entry 31, got %d", row)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/xuri/excelize/v2"
+)
+
+const WATCH_INTERVAL = 2 * time.Second
+
+// fileState identifies a version of the workbook on disk.
+type fileState struct {
+	ModTime time.Time
+	Size    int64
+	Hash    [sha256.Size]byte
+}
+
+type workbookCheckMsg struct{}
+
+func readFileState(path string) (fileState, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return fileState{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fileState{}, err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fileState{}, err
+	}
+	state := fileState{ModTime: info.ModTime(), Size: info.Size()}
+	copy(state.Hash[:], hash.Sum(nil))
+	return state, nil
+}
+
+// changedSince reports whether the file at path differs from state. The
+// content is only hashed if modification time or size changed.
+func changedSince(path string, state fileState) (bool, fileState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, state, err
+	}
+	if info.ModTime().Equal(state.ModTime) && info.Size() == state.Size {
+		return false, state, nil
+	}
+	current, err := readFileState(path)
+	if err != nil {
+		return false, state, err
+	}
+	return current.Hash != state.Hash, current, nil
+}
+
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(infoA, infoB)
+}
+
+func watchWorkbook() tea.Cmd {
+	return tea.Tick(WATCH_INTERVAL, func(time.Time) tea.Msg {
+		return workbookCheckMsg{}
+	})
+}
+
+// checkWorkbook reloads the workbook if it was changed by someone else and
+// merges those changes with the ones in memory.
+func (m *Model) checkWorkbook() {
+	changed, state, err := changedSince(m.config.ExcelFileName, m.fileState)
+	if err != nil {
+		slog.Warn("Could not check workbook for external changes", "file", m.config.ExcelFileName, "error", err)
+		return
+	}
+	if !changed {
+		m.fileState = state
+		return
+	}
+
+	slog.Warn("Workbook was changed on disk, reloading", "file", m.config.ExcelFileName)
+	f, err := excelize.OpenFile(m.config.ExcelFileName, excelize.Options{RawCellValue: true})
+	if err != nil {
+		// the other program might still be writing it, try again later
+		slog.Error("Failed to reload changed workbook", "file", m.config.ExcelFileName, "error", err)
+		return
+	}
+	m.config.ExcelFile.Close()
+	m.config.ExcelFile = f
+	m.fileState = state
+
+	theirs := ReturnAll(m.config)
+	merged, conflicts := MergeEntries(m.base, m.entryList.Entries, theirs)
+	m.entryList.Entries = merged
+	m.base = copyEntries(theirs)
+	m.clampSelectedRow()
+
+	// unsaved changes are now the ones differing from the reloaded workbook
+	clear(m.modified)
+	for i := range merged {
+		for j := range merged[i] {
+			if !daysEqual(merged[i][j], dayAt(theirs, i, j)) {
+				date := m.dayDate(time.Month(i+1), j+1)
+				m.markModified(date, date)
+			}
+		}
+	}
+
+	m.conflicts = conflicts
+	m.conflictIndex = 0
+	m.debugMessage = trf("Workbook was changed on disk! Merged the changes, %d conflicts.", len(conflicts))
+}
+
+func (m *Model) nextConflict() {
+	m.conflictIndex += 1
+	if m.conflictIndex >= len(m.conflicts) {
+		m.conflicts = nil
+		m.debugMessage = tr("Resolved all conflicts")
+	}
+}
+
+func (m Model) updateConflicts(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.ConflictOurs):
+		m.nextConflict()
+	case key.Matches(msg, keys.ConflictTheirs):
+		conflict := m.conflicts[m.conflictIndex]
+		m.entryList.ResolveConflictWithTheirs(conflict)
+		date := m.dayDate(conflict.Date.Month(), conflict.Date.Day())
+		if daysEqual(*m.entryList.dayEntries(date), dayAt(m.base, int(date.Month())-1, date.Day()-1)) {
+			delete(m.modified, date)
+		} else {
+			m.markModified(date, date)
+		}
+		m.clampSelectedRow()
+		m.nextConflict()
+	case key.Matches(msg, keys.Quit):
+		return m, m.quit()
+	}
+	return m, nil
+}
+
+func (m Model) viewConflicts() string {
+	conflict := m.conflicts[m.conflictIndex]
+	describe := func(entry *RowEntry) string {
+		if entry == nil {
+			return tr("(none)")
+		}
+		return entry.View()
+	}
+
+	s := m.styles["inputFieldErr"].Render(trf(
+		"Conflict %d/%d on %s: entry changed here and in the workbook on disk",
+		m.conflictIndex+1, len(m.conflicts), formatDate(conflict.Date, "Mon 02.01.2006"),
+	)) + "\n"
+	s += fmt.Sprintf("  %-10s %s\n", tr("Original:"), describe(conflict.Base))
+	s += fmt.Sprintf("  %-10s %s\n", tr("Mine:"), m.styles["selectedEntry"].Render(describe(conflict.Ours)))
+	s += fmt.Sprintf("  %-10s %s\n", tr("Theirs:"), describe(conflict.Theirs))
+	s += "\n" + viewChoices(keys.ConflictOurs, keys.ConflictTheirs) + "\n"
+	return s
+}
@@ -0,0 +1,31 @@
+package main
+
+import "time"
+
+// WorkTime is worked time split into work on regular workdays and work on
+// weekends and holidays, which is paid with a surcharge.
+type WorkTime struct {
+	Workdays time.Duration
+	DaysOff  time.Duration
+}
+
+func (w WorkTime) Total() time.Duration {
+	return w.Workdays + w.DaysOff
+}
+
+// SumWorkTime sums up the worked time of all entries of the given days.
+func SumWorkTime(days [][]RowEntry, config Configuration) WorkTime {
+	var res WorkTime
+	for _, day := range days {
+		for _, entry := range day {
+			for _, part := range entry.Parts() {
+				if isDayOff(part.Date, config) {
+					res.DaysOff += part.Duration()
+				} else {
+					res.Workdays += part.Duration()
+				}
+			}
+		}
+	}
+	return res
+}